@@ -0,0 +1,107 @@
+package aggregator
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/ThreeAndTwo/dex-swap-api-helper/kyberswap"
+	"github.com/ThreeAndTwo/dex-swap-api-helper/odos"
+)
+
+// Aggregator quotes swaps on one provider, letting Comparer and
+// FailoverClient treat the providers alike. An implementation that also
+// has a CircuitOpen() bool method, like the adapters of this package, is
+// skipped by FailoverClient while its circuit breaker is open.
+type Aggregator interface {
+	// Name returns the provider name reported in quotes
+	Name() string
+	// Quote quotes params, the returned quote has Err set when err isn't nil
+	Quote(params NormalizedParams) (ProviderQuote, error)
+}
+
+// circuitBreaker is implemented by aggregators that can fast-fail
+type circuitBreaker interface {
+	CircuitOpen() bool
+}
+
+// Odos returns the Aggregator quoting on client
+func Odos(client *odos.OdosClient) Aggregator {
+	return odosAggregator{client}
+}
+
+// KyberSwap returns the Aggregator quoting on client, on the chain of the
+// swap rather than the one client is set to
+func KyberSwap(client *kyberswap.KyberSwapClient) Aggregator {
+	return kyberAggregator{client}
+}
+
+type odosAggregator struct {
+	client *odos.OdosClient
+}
+
+func (a odosAggregator) Name() string { return ProviderOdos }
+
+func (a odosAggregator) CircuitOpen() bool { return a.client.CircuitOpen() }
+
+func (a odosAggregator) Quote(params NormalizedParams) (ProviderQuote, error) {
+	quote := ProviderQuote{Provider: ProviderOdos}
+
+	req := odos.NewQuoteRequest(params.Chain, nil, nil).
+		AddInput(params.TokenIn, params.AmountIn).
+		SetSingleOutput(params.TokenOut).
+		WithUserAddr(params.UserAddr)
+	quoteResp, err := a.client.Quote(req)
+	if err != nil {
+		quote.Err = err
+		return quote, err
+	}
+	if len(quoteResp.OutAmounts) == 0 {
+		quote.Err = fmt.Errorf("quote has no output amount")
+		return quote, quote.Err
+	}
+
+	quote.AmountOut = quoteResp.OutAmounts[0]
+	for _, value := range quoteResp.OutValues {
+		quote.AmountOutUsd += value
+	}
+	quote.GasUsd = quoteResp.TotalGasCostUSD()
+	quote.NetUsd = quote.AmountOutUsd - quote.GasUsd
+	return quote, nil
+}
+
+type kyberAggregator struct {
+	client *kyberswap.KyberSwapClient
+}
+
+func (a kyberAggregator) Name() string { return ProviderKyberSwap }
+
+func (a kyberAggregator) CircuitOpen() bool { return a.client.CircuitOpen() }
+
+func (a kyberAggregator) Quote(params NormalizedParams) (ProviderQuote, error) {
+	quote := ProviderQuote{Provider: ProviderKyberSwap}
+
+	chain, err := kyberswap.ChainName(params.Chain)
+	if err != nil {
+		quote.Err = err
+		return quote, err
+	}
+
+	best, _, err := a.client.BestAcrossChains(context.Background(), []kyberswap.ChainQuery{{
+		Chain:    chain,
+		TokenIn:  params.TokenIn,
+		TokenOut: params.TokenOut,
+		AmountIn: params.AmountIn,
+	}})
+	if err != nil {
+		quote.Err = err
+		return quote, err
+	}
+
+	summary := best.Route.Data.RouteSummary
+	quote.AmountOut = summary.AmountOut
+	quote.AmountOutUsd, _ = strconv.ParseFloat(summary.AmountOutUsd, 64)
+	quote.GasUsd, _ = strconv.ParseFloat(summary.GasUsd, 64)
+	quote.NetUsd = best.NetUsd
+	return quote, nil
+}
@@ -0,0 +1,140 @@
+// Package aggregator compares the quotes of the supported DEX aggregators
+// for the same swap, or fails over from one to the next.
+package aggregator
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ThreeAndTwo/dex-swap-api-helper/chains"
+	"github.com/ThreeAndTwo/dex-swap-api-helper/kyberswap"
+	"github.com/ThreeAndTwo/dex-swap-api-helper/odos"
+	"github.com/ThreeAndTwo/dex-swap-api-helper/units"
+)
+
+// Provider names used in comparison results
+const (
+	ProviderOdos      = "odos"
+	ProviderKyberSwap = "kyberswap"
+)
+
+// NormalizedParams describes a swap independently of the aggregator. Token
+// addresses may use the native token marker of either client, each client
+// translates it to its own representation.
+type NormalizedParams struct {
+	Chain    chains.ChainID
+	TokenIn  string
+	TokenOut string
+	// AmountIn is the raw integer amount of TokenIn
+	AmountIn string
+	// TokenOutDecimals formats the output amounts, 0 looks the decimals up
+	// in the Odos token list
+	TokenOutDecimals int
+	// UserAddr is passed to Odos when set, it isn't required to quote
+	UserAddr string
+}
+
+// ProviderQuote is the normalized quote of one provider, Err is set when
+// the provider failed
+type ProviderQuote struct {
+	Provider string
+	// AmountOut is the raw integer amount of TokenOut
+	AmountOut string
+	// AmountOutFormatted is AmountOut in token units, empty when the
+	// decimals of TokenOut are unknown
+	AmountOutFormatted string
+	AmountOutUsd       float64
+	GasUsd             float64
+	// NetUsd is AmountOutUsd minus GasUsd
+	NetUsd float64
+	Err    error
+}
+
+// ComparisonResult holds the quote of every provider and the winner, the
+// provider with the highest net output
+type ComparisonResult struct {
+	Quotes []ProviderQuote
+	Winner string
+}
+
+// Comparer quotes the same swap on Odos and KyberSwap
+type Comparer struct {
+	odos  *odos.OdosClient
+	kyber *kyberswap.KyberSwapClient
+}
+
+// NewComparer creates a Comparer using the given clients
+func NewComparer(odosClient *odos.OdosClient, kyberClient *kyberswap.KyberSwapClient) *Comparer {
+	return &Comparer{
+		odos:  odosClient,
+		kyber: kyberClient,
+	}
+}
+
+// Compare quotes params on both providers concurrently and picks the one
+// with the highest net USD output, ties going to the larger raw output. A
+// failing provider is reported in its quote, an error is only returned when
+// every provider failed.
+func (c *Comparer) Compare(params NormalizedParams) (ComparisonResult, error) {
+	if _, err := kyberswap.ChainName(params.Chain); err != nil {
+		return ComparisonResult{}, err
+	}
+
+	providers := []Aggregator{Odos(c.odos), KyberSwap(c.kyber)}
+	quotes := make([]ProviderQuote, len(providers))
+	var wg sync.WaitGroup
+	wg.Add(len(providers))
+	for i, provider := range providers {
+		go func() {
+			defer wg.Done()
+			quotes[i], _ = provider.Quote(params)
+		}()
+	}
+	wg.Wait()
+
+	decimals := params.TokenOutDecimals
+	if decimals == 0 {
+		if info, err := c.odos.GetTokenInfo(params.Chain, params.TokenOut); err == nil {
+			decimals = info.Decimals
+		}
+	}
+
+	result := ComparisonResult{Quotes: quotes}
+	var best *ProviderQuote
+	var errs []error
+	for i := range quotes {
+		quote := &quotes[i]
+		if quote.Err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", quote.Provider, quote.Err))
+			continue
+		}
+		if decimals != 0 {
+			quote.AmountOutFormatted, _ = units.FormatAmount(quote.AmountOut, decimals)
+		}
+		if best == nil || beats(quote, best) {
+			best = quote
+		}
+	}
+
+	if best == nil {
+		return result, fmt.Errorf("no provider could quote the swap: %w", errors.Join(errs...))
+	}
+	result.Winner = best.Provider
+	return result, nil
+}
+
+// beats reports whether quote is better than best
+func beats(quote, best *ProviderQuote) bool {
+	if quote.NetUsd != best.NetUsd {
+		return quote.NetUsd > best.NetUsd
+	}
+
+	amount, ok := new(big.Int).SetString(quote.AmountOut, 10)
+	if !ok {
+		return false
+	}
+	bestAmount, ok := new(big.Int).SetString(best.AmountOut, 10)
+	return !ok || amount.Cmp(bestAmount) > 0
+}
@@ -0,0 +1,85 @@
+package aggregator
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/ThreeAndTwo/dex-swap-api-helper/chains"
+	"github.com/ThreeAndTwo/dex-swap-api-helper/kyberswap"
+	"github.com/ThreeAndTwo/dex-swap-api-helper/odos"
+)
+
+const (
+	DAI   = "0x6B175474E89094C44Da98b954EedeAC495271d0F"
+	sUSDe = "0x9D39A5DE30e57443BfF2A8307A4256c8797A3497"
+)
+
+func TestComparer_Compare(t *testing.T) {
+	odosServer, odosClient := odos.NewTestServer(odos.TestHandlers{})
+	defer odosServer.Close()
+	kyberServer, kyberClient := kyberswap.NewTestServer(kyberswap.TestHandlers{
+		Routes: func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `{"code":0,"data":{"routeSummary":{"amountOut":"880000000000000000","amountOutUsd":"1.0061","gasUsd":"0.5"}}}`)
+		},
+	})
+	defer kyberServer.Close()
+
+	result, err := NewComparer(odosClient, kyberClient).Compare(NormalizedParams{
+		Chain:    chains.Ethereum,
+		TokenIn:  DAI,
+		TokenOut: sUSDe,
+		AmountIn: "1000000000000000000",
+	})
+	if err != nil {
+		t.Fatalf("Compare() error = %v", err)
+	}
+
+	if result.Winner != ProviderKyberSwap {
+		t.Errorf("Winner = %s, want %s", result.Winner, ProviderKyberSwap)
+	}
+	if len(result.Quotes) != 2 {
+		t.Fatalf("got %d quotes, want 2", len(result.Quotes))
+	}
+
+	odosQuote, kyberQuote := result.Quotes[0], result.Quotes[1]
+	if odosQuote.Provider != ProviderOdos || odosQuote.AmountOutFormatted != "0.874518826958614826" {
+		t.Errorf("odos quote = %+v", odosQuote)
+	}
+	if kyberQuote.AmountOutFormatted != "0.88" || kyberQuote.NetUsd != 1.0061-0.5 {
+		t.Errorf("kyberswap quote = %+v", kyberQuote)
+	}
+}
+
+func TestComparer_CompareProviderFailure(t *testing.T) {
+	odosServer, odosClient := odos.NewTestServer(odos.TestHandlers{})
+	defer odosServer.Close()
+	kyberServer, kyberClient := kyberswap.NewTestServer(kyberswap.TestHandlers{
+		Routes: func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"code":4008,"message":"route not found"}`)
+		},
+	})
+	defer kyberServer.Close()
+
+	comparer := NewComparer(odosClient, kyberClient)
+	params := NormalizedParams{Chain: chains.Ethereum, TokenIn: DAI, TokenOut: sUSDe, AmountIn: "1000000000000000000", TokenOutDecimals: 18}
+	result, err := comparer.Compare(params)
+	if err != nil {
+		t.Fatalf("Compare() error = %v", err)
+	}
+	if result.Winner != ProviderOdos || !errors.Is(result.Quotes[1].Err, kyberswap.ErrNoRoute) {
+		t.Errorf("Compare() = %+v", result)
+	}
+
+	odosServer.Close()
+	if _, err := comparer.Compare(params); err == nil {
+		t.Errorf("Compare() succeeded with every provider failing")
+	}
+
+	params.Chain = chains.Mode
+	if _, err := comparer.Compare(params); err == nil {
+		t.Errorf("Compare() succeeded on an unknown chain")
+	}
+}
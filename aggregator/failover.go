@@ -0,0 +1,53 @@
+package aggregator
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ThreeAndTwo/dex-swap-api-helper/odos"
+)
+
+var (
+	// ErrAllProvidersFailed is returned by FailoverClient when no provider
+	// could quote the swap, joined with the error of every provider
+	ErrAllProvidersFailed = errors.New("aggregator: all providers failed")
+	// ErrCircuitOpen is reported for providers skipped because their circuit
+	// breaker is open, the same error both clients fast-fail with
+	ErrCircuitOpen = odos.ErrCircuitOpen
+)
+
+// FailoverClient quotes a swap on the first of its providers that succeeds,
+// falling back to the next one when a provider fails or finds no route
+type FailoverClient struct {
+	providers []Aggregator
+}
+
+// NewFailoverClient creates a FailoverClient trying providers in the given
+// priority order, e.g.
+//
+//	NewFailoverClient(aggregator.Odos(odosClient), aggregator.KyberSwap(kyberClient))
+func NewFailoverClient(providers ...Aggregator) *FailoverClient {
+	return &FailoverClient{providers: providers}
+}
+
+// Quote returns the quote of the first provider that succeeds, skipping the
+// providers whose circuit breaker is open without calling them. When every
+// provider fails the error wraps ErrAllProvidersFailed along with the error
+// of each provider, prefixed by its name.
+func (f *FailoverClient) Quote(params NormalizedParams) (ProviderQuote, error) {
+	var errs []error
+	for _, provider := range f.providers {
+		if breaker, ok := provider.(circuitBreaker); ok && breaker.CircuitOpen() {
+			errs = append(errs, fmt.Errorf("%s: %w", provider.Name(), ErrCircuitOpen))
+			continue
+		}
+
+		quote, err := provider.Quote(params)
+		if err == nil {
+			return quote, nil
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", provider.Name(), err))
+	}
+
+	return ProviderQuote{}, fmt.Errorf("%w: %w", ErrAllProvidersFailed, errors.Join(errs...))
+}
@@ -0,0 +1,52 @@
+package aggregator
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ThreeAndTwo/dex-swap-api-helper/chains"
+	"github.com/ThreeAndTwo/dex-swap-api-helper/kyberswap"
+	"github.com/ThreeAndTwo/dex-swap-api-helper/odos"
+)
+
+func TestFailoverClient_Quote(t *testing.T) {
+	var odosCalls int
+	odosServer, odosClient := odos.NewTestServer(odos.TestHandlers{
+		Quote: func(w http.ResponseWriter, r *http.Request) {
+			odosCalls++
+			w.WriteHeader(http.StatusInternalServerError)
+		},
+	}, odos.WithCircuitBreaker(1, time.Minute))
+	defer odosServer.Close()
+	kyberServer, kyberClient := kyberswap.NewTestServer(kyberswap.TestHandlers{
+		Routes: func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `{"code":0,"data":{"routeSummary":{"amountOut":"880000000000000000","amountOutUsd":"1.0061","gasUsd":"0.5"}}}`)
+		},
+	})
+	defer kyberServer.Close()
+
+	failover := NewFailoverClient(Odos(odosClient), KyberSwap(kyberClient))
+	params := NormalizedParams{Chain: chains.Ethereum, TokenIn: DAI, TokenOut: sUSDe, AmountIn: "1000000000000000000"}
+
+	for i := 0; i < 2; i++ {
+		quote, err := failover.Quote(params)
+		if err != nil {
+			t.Fatalf("Quote() error = %v", err)
+		}
+		if quote.Provider != ProviderKyberSwap || quote.AmountOut != "880000000000000000" {
+			t.Errorf("Quote() = %+v", quote)
+		}
+	}
+	if odosCalls != 1 {
+		t.Errorf("odos called %d times, want 1 before its circuit opened", odosCalls)
+	}
+
+	kyberServer.Close()
+	_, err := failover.Quote(params)
+	if !errors.Is(err, ErrAllProvidersFailed) || !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("Quote() error = %v, want ErrAllProvidersFailed with the odos circuit open", err)
+	}
+}
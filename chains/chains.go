@@ -0,0 +1,77 @@
+// Package chains names the EVM chains the aggregator clients route on, giving
+// both clients one canonical chain identifier
+package chains
+
+import "strconv"
+
+// ChainID is an EVM chain ID
+type ChainID int64
+
+// Known chains
+const (
+	Ethereum     ChainID = 1
+	Optimism     ChainID = 10
+	BSC          ChainID = 56
+	Unichain     ChainID = 130
+	Polygon      ChainID = 137
+	Sonic        ChainID = 146
+	Fantom       ChainID = 250
+	ZkSync       ChainID = 324
+	PolygonZkEVM ChainID = 1101
+	Ronin        ChainID = 2020
+	Mantle       ChainID = 5000
+	Base         ChainID = 8453
+	Mode         ChainID = 34443
+	Arbitrum     ChainID = 42161
+	Avalanche    ChainID = 43114
+	Linea        ChainID = 59144
+	Berachain    ChainID = 80094
+	Blast        ChainID = 81457
+	Scroll       ChainID = 534352
+)
+
+var names = map[ChainID]string{
+	Ethereum:     "ethereum",
+	Optimism:     "optimism",
+	BSC:          "bsc",
+	Unichain:     "unichain",
+	Polygon:      "polygon",
+	Sonic:        "sonic",
+	Fantom:       "fantom",
+	ZkSync:       "zksync",
+	PolygonZkEVM: "polygon-zkevm",
+	Ronin:        "ronin",
+	Mantle:       "mantle",
+	Base:         "base",
+	Mode:         "mode",
+	Arbitrum:     "arbitrum",
+	Avalanche:    "avalanche",
+	Linea:        "linea",
+	Berachain:    "berachain",
+	Blast:        "blast",
+	Scroll:       "scroll",
+}
+
+// String returns the lowercase name of a known chain, the decimal ID otherwise
+func (id ChainID) String() string {
+	if name, ok := names[id]; ok {
+		return name
+	}
+	return strconv.FormatInt(int64(id), 10)
+}
+
+// IsKnown reports whether id is one of the named chains
+func (id ChainID) IsKnown() bool {
+	_, ok := names[id]
+	return ok
+}
+
+// Parse returns the chain named name, as returned by String
+func Parse(name string) (ChainID, bool) {
+	for id, n := range names {
+		if n == name {
+			return id, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,37 @@
+package chains
+
+import "testing"
+
+func TestChainID_String(t *testing.T) {
+	tests := []struct {
+		id   ChainID
+		want string
+	}{
+		{Ethereum, "ethereum"},
+		{Arbitrum, "arbitrum"},
+		{PolygonZkEVM, "polygon-zkevm"},
+		{ChainID(999999), "999999"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.id.String(); got != tt.want {
+			t.Errorf("ChainID(%d).String() = %q, want %q", int64(tt.id), got, tt.want)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	for id := range names {
+		got, ok := Parse(id.String())
+		if !ok || got != id {
+			t.Errorf("Parse(%q) = %d, %v, want %d", id.String(), got, ok, id)
+		}
+	}
+
+	if _, ok := Parse("etherium"); ok {
+		t.Error("Parse() accepted an unknown chain")
+	}
+	if ChainID(999999).IsKnown() {
+		t.Error("IsKnown() = true for an unknown chain")
+	}
+}
@@ -2,10 +2,14 @@ module github.com/ThreeAndTwo/dex-swap-api-helper
 
 go 1.22.2
 
-require github.com/rs/zerolog v1.33.0
+require (
+	github.com/andybalholm/brotli v1.1.1
+	github.com/rs/zerolog v1.33.0
+	golang.org/x/crypto v0.31.0
+)
 
 require (
 	github.com/mattn/go-colorable v0.1.13 // indirect
 	github.com/mattn/go-isatty v0.0.19 // indirect
-	golang.org/x/sys v0.12.0 // indirect
+	golang.org/x/sys v0.28.0 // indirect
 )
@@ -0,0 +1,90 @@
+// Package address validates and normalizes EVM addresses before they are
+// sent to the aggregator APIs.
+package address
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/sha3"
+)
+
+// ErrInvalid is returned for strings that aren't valid EVM addresses
+var ErrInvalid = errors.New("invalid address")
+
+// Normalize validates addr and returns it lowercased. addr must be a 0x
+// prefixed 20 byte hex string, and match its EIP-55 checksum when mixed case.
+func Normalize(addr string) (string, error) {
+	if len(addr) != 42 || !strings.HasPrefix(addr, "0x") && !strings.HasPrefix(addr, "0X") {
+		return "", fmt.Errorf("%w %q: want 0x followed by 40 hex characters", ErrInvalid, addr)
+	}
+
+	digits := addr[2:]
+	if _, err := hex.DecodeString(digits); err != nil {
+		return "", fmt.Errorf("%w %q: non hex characters", ErrInvalid, addr)
+	}
+
+	lower := strings.ToLower(digits)
+	if digits != lower && digits != strings.ToUpper(digits) && digits != checksum(lower) {
+		return "", fmt.Errorf("%w %q: EIP-55 checksum mismatch", ErrInvalid, addr)
+	}
+
+	return "0x" + lower, nil
+}
+
+// Validate returns an error if addr isn't a valid address
+func Validate(addr string) error {
+	_, err := Normalize(addr)
+	return err
+}
+
+// Checksum returns the EIP-55 mixed case form of a valid address
+func Checksum(addr string) (string, error) {
+	lower, err := Normalize(addr)
+	if err != nil {
+		return "", err
+	}
+	return "0x" + checksum(lower[2:]), nil
+}
+
+// checksum applies the EIP-55 casing to 40 lowercase hex digits
+func checksum(lower string) string {
+	hash := sha3.NewLegacyKeccak256()
+	hash.Write([]byte(lower))
+	sum := hash.Sum(nil)
+
+	out := []byte(lower)
+	for i, c := range out {
+		nibble := sum[i/2]
+		if i%2 == 0 {
+			nibble >>= 4
+		}
+		if c >= 'a' && nibble&0xf >= 8 {
+			out[i] = c - 'a' + 'A'
+		}
+	}
+	return string(out)
+}
+
+const (
+	// Native is the canonical marker for a chain native token such as ETH
+	Native = "0xEeeeeEeeeEeEeeEeEeEeeEEEeeeeEeeeeeeeEEeE"
+	// Zero is the zero address, used by some aggregators for the native token
+	Zero = "0x0000000000000000000000000000000000000000"
+)
+
+// IsNative reports whether addr is one of the native token sentinels
+func IsNative(addr string) bool {
+	return strings.EqualFold(addr, Native) || strings.EqualFold(addr, Zero)
+}
+
+// Redact shortens addr for logging, keeping enough to tell addresses apart
+// without exposing the full user address
+func Redact(addr string) string {
+	if len(addr) <= 10 {
+		return addr
+	}
+	return addr[:6] + "..." + addr[len(addr)-4:]
+}
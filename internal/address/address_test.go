@@ -0,0 +1,73 @@
+package address
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name    string
+		addr    string
+		want    string
+		wantErr bool
+	}{
+		{name: "checksummed", addr: "0x6B175474E89094C44Da98b954EedeAC495271d0F", want: "0x6b175474e89094c44da98b954eedeac495271d0f"},
+		{name: "lowercase", addr: "0xdac17f958d2ee523a2206206994597c13d831ec7", want: "0xdac17f958d2ee523a2206206994597c13d831ec7"},
+		{name: "native sentinel", addr: "0xEeeeeEeeeEeEeeEeEeEeeEEEeeeeEeeeeeeeEEeE", want: "0xeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeee"},
+		{name: "bad checksum", addr: "0x6b175474E89094C44Da98b954EedeAC495271d0F", wantErr: true},
+		{name: "too short", addr: "0x6B175474E89094C44Da98b954EedeAC495271d0", wantErr: true},
+		{name: "no prefix", addr: "6B175474E89094C44Da98b954EedeAC495271d0Fab", wantErr: true},
+		{name: "non hex", addr: "0x6B175474E89094C44Da98b954EedeAC495271d0G", wantErr: true},
+		{name: "empty", addr: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Normalize(tt.addr)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Normalize() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalid) {
+				t.Errorf("Normalize() error = %v, want ErrInvalid", err)
+			}
+			if got != tt.want {
+				t.Errorf("Normalize() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChecksum(t *testing.T) {
+	got, err := Checksum("0x6b175474e89094c44da98b954eedeac495271d0f")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "0x6B175474E89094C44Da98b954EedeAC495271d0F" {
+		t.Errorf("Checksum() = %s", got)
+	}
+}
+
+func TestIsNative(t *testing.T) {
+	for _, addr := range []string{Native, Zero, "0xeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeee"} {
+		if !IsNative(addr) {
+			t.Errorf("IsNative(%s) = false", addr)
+		}
+	}
+	if IsNative("0x6B175474E89094C44Da98b954EedeAC495271d0F") {
+		t.Errorf("IsNative(DAI) = true")
+	}
+}
+
+func TestRedact(t *testing.T) {
+	tests := map[string]string{
+		"0x163A5EC5e9C32238d075E2D829fE9fA87451e3b7": "0x163A...e3b7",
+		"":       "",
+		"0x1234": "0x1234",
+	}
+	for addr, want := range tests {
+		if got := Redact(addr); got != want {
+			t.Errorf("Redact(%q) = %q, want %q", addr, got, want)
+		}
+	}
+}
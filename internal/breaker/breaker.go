@@ -0,0 +1,80 @@
+// Package breaker implements the circuit breaker shared by the aggregator
+// clients, fast-failing calls to a provider that keeps failing.
+package breaker
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrOpen is returned by Allow while the circuit is open
+var ErrOpen = errors.New("circuit breaker open")
+
+// Breaker opens after threshold consecutive failures. Once cooldown elapsed
+// a single probe call is let through, closing the circuit on success and
+// reopening it for another cooldown on failure.
+type Breaker struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	failures  int
+	openedAt  time.Time
+	probing   bool
+	now       func() time.Time
+}
+
+// New creates a closed breaker, a threshold below 1 is treated as 1
+func New(threshold int, cooldown time.Duration) *Breaker {
+	if threshold < 1 {
+		threshold = 1
+	}
+	return &Breaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+		now:       time.Now,
+	}
+}
+
+// Allow returns ErrOpen when the call must fast-fail, otherwise the caller
+// must report the outcome of the call with Record
+func (b *Breaker) Allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.failures < b.threshold {
+		return nil
+	}
+	if b.probing || b.now().Sub(b.openedAt) < b.cooldown {
+		return ErrOpen
+	}
+
+	b.probing = true
+	return nil
+}
+
+// Open reports whether Allow would fast-fail now, without letting a probe
+// call through
+func (b *Breaker) Open() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.failures >= b.threshold && (b.probing || b.now().Sub(b.openedAt) < b.cooldown)
+}
+
+// Record reports the outcome of a call let through by Allow
+func (b *Breaker) Record(success bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+	if success {
+		b.failures = 0
+		return
+	}
+
+	b.failures++
+	if b.failures >= b.threshold {
+		b.openedAt = b.now()
+	}
+}
@@ -0,0 +1,79 @@
+package breaker
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestBreaker(t *testing.T) {
+	now := time.Unix(0, 0)
+	b := New(2, time.Minute)
+	b.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		if err := b.Allow(); err != nil {
+			t.Fatalf("Allow() before threshold error = %v", err)
+		}
+		b.Record(false)
+	}
+	if err := b.Allow(); !errors.Is(err, ErrOpen) {
+		t.Fatalf("Allow() after threshold error = %v, want ErrOpen", err)
+	}
+
+	now = now.Add(time.Minute)
+	if err := b.Allow(); err != nil {
+		t.Fatalf("Allow() probe error = %v", err)
+	}
+	if err := b.Allow(); !errors.Is(err, ErrOpen) {
+		t.Fatalf("Allow() during probe error = %v, want ErrOpen", err)
+	}
+	b.Record(false)
+	if err := b.Allow(); !errors.Is(err, ErrOpen) {
+		t.Fatalf("Allow() after failed probe error = %v, want ErrOpen", err)
+	}
+
+	now = now.Add(time.Minute)
+	if err := b.Allow(); err != nil {
+		t.Fatalf("Allow() second probe error = %v", err)
+	}
+	b.Record(true)
+	if err := b.Allow(); err != nil {
+		t.Fatalf("Allow() after successful probe error = %v", err)
+	}
+}
+
+func TestBreaker_SuccessResetsFailures(t *testing.T) {
+	b := New(2, time.Minute)
+	b.Record(false)
+	b.Record(true)
+	b.Record(false)
+	if err := b.Allow(); err != nil {
+		t.Fatalf("Allow() error = %v, failures must be consecutive", err)
+	}
+}
+
+func TestBreaker_Open(t *testing.T) {
+	now := time.Unix(0, 0)
+	b := New(1, time.Minute)
+	b.now = func() time.Time { return now }
+
+	if b.Open() {
+		t.Fatal("Open() of a new breaker")
+	}
+	b.Record(false)
+	if !b.Open() {
+		t.Fatal("Open() = false after threshold")
+	}
+
+	now = now.Add(time.Minute)
+	if b.Open() {
+		t.Fatal("Open() = true once cooldown elapsed")
+	}
+	if err := b.Allow(); err != nil {
+		t.Fatalf("Allow() probe error = %v, Open() must not start the probe", err)
+	}
+	if !b.Open() {
+		t.Error("Open() = false during probe")
+	}
+}
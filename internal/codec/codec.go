@@ -0,0 +1,31 @@
+// Package codec abstracts the JSON implementation encoding the requests and
+// decoding the responses of the clients.
+package codec
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// Codec marshals and unmarshals JSON. Implementations must honour the
+// json.Marshaler and json.Unmarshaler methods of the types they handle, as
+// json-iterator and Sonic do in their encoding/json compatible modes.
+type Codec interface {
+	Marshal(v interface{}) ([]byte, error)
+	Unmarshal(data []byte, v interface{}) error
+}
+
+// Standard is the encoding/json codec used by default
+var Standard Codec = standard{}
+
+type standard struct{}
+
+func (standard) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+// Unmarshal decodes the first JSON value of data. It uses a Decoder rather
+// than json.Unmarshal so a truncated document reports io.ErrUnexpectedEOF.
+func (standard) Unmarshal(data []byte, v interface{}) error {
+	return json.NewDecoder(bytes.NewReader(data)).Decode(v)
+}
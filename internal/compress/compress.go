@@ -0,0 +1,85 @@
+// Package compress decodes the response bodies the HTTP transport left
+// encoded, e.g. brotli forced by a CDN or gzip when the transport did not
+// negotiate the encoding itself.
+package compress
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/andybalholm/brotli"
+)
+
+// ErrUnsupported is returned by Decode for a content encoding it cannot decode
+var ErrUnsupported = errors.New("unsupported content encoding")
+
+// Decode replaces the body of resp with a reader decoding its
+// Content-Encoding, applied encodings are undone in reverse order. Bodies
+// already decoded by the transport are left untouched. On error the body is
+// closed.
+func Decode(resp *http.Response) error {
+	encoding := resp.Header.Get("Content-Encoding")
+	if resp.Uncompressed || encoding == "" {
+		return nil
+	}
+
+	body := resp.Body
+	closers := []io.Closer{resp.Body}
+	var reader io.Reader = body
+
+	codings := strings.Split(encoding, ",")
+	for i := len(codings) - 1; i >= 0; i-- {
+		var err error
+		switch coding := strings.ToLower(strings.TrimSpace(codings[i])); coding {
+		case "", "identity":
+			continue
+		case "gzip", "x-gzip":
+			var zr *gzip.Reader
+			if zr, err = gzip.NewReader(reader); err == nil {
+				reader, closers = zr, append(closers, zr)
+			}
+		case "deflate":
+			var zr io.ReadCloser
+			if zr, err = zlib.NewReader(reader); err == nil {
+				reader, closers = zr, append(closers, zr)
+			}
+		case "br":
+			reader = brotli.NewReader(reader)
+		default:
+			err = fmt.Errorf("%w %q", ErrUnsupported, coding)
+		}
+		if err != nil {
+			body.Close()
+			return err
+		}
+	}
+
+	resp.Body = &decodedBody{Reader: reader, closers: closers}
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+	return nil
+}
+
+// decodedBody reads the decoded stream and closes the decoders along with
+// the underlying body
+type decodedBody struct {
+	io.Reader
+	closers []io.Closer
+}
+
+func (b *decodedBody) Close() error {
+	var err error
+	for i := len(b.closers) - 1; i >= 0; i-- {
+		if cerr := b.closers[i].Close(); err == nil {
+			err = cerr
+		}
+	}
+	return err
+}
@@ -0,0 +1,104 @@
+package compress
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+)
+
+const payload = `{"code":0,"message":"successfully"}`
+
+func encode(t *testing.T, coding string, data []byte) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	var w io.WriteCloser
+	switch coding {
+	case "gzip":
+		w = gzip.NewWriter(&buf)
+	case "deflate":
+		w = zlib.NewWriter(&buf)
+	case "br":
+		w = brotli.NewWriter(&buf)
+	default:
+		t.Fatalf("unknown coding %q", coding)
+	}
+	w.Write(data)
+	w.Close()
+	return buf.Bytes()
+}
+
+func response(encoding string, body []byte) *http.Response {
+	resp := &http.Response{
+		Header:        http.Header{},
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+	}
+	if encoding != "" {
+		resp.Header.Set("Content-Encoding", encoding)
+	}
+	return resp
+}
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name     string
+		encoding string
+		body     []byte
+	}{
+		{"identity", "", []byte(payload)},
+		{"gzip", "gzip", encode(t, "gzip", []byte(payload))},
+		{"deflate", "deflate", encode(t, "deflate", []byte(payload))},
+		{"brotli", "br", encode(t, "br", []byte(payload))},
+		{"stacked", "gzip, br", encode(t, "br", encode(t, "gzip", []byte(payload)))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := response(tt.encoding, tt.body)
+			if err := Decode(resp); err != nil {
+				t.Fatalf("Decode() error = %v", err)
+			}
+			defer resp.Body.Close()
+
+			got, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatalf("ReadAll() error = %v", err)
+			}
+			if string(got) != payload {
+				t.Errorf("body = %q, want %q", got, payload)
+			}
+			if tt.encoding != "" && (resp.Header.Get("Content-Encoding") != "" || !resp.Uncompressed) {
+				t.Errorf("response still marked encoded: %v, Uncompressed = %v", resp.Header, resp.Uncompressed)
+			}
+		})
+	}
+}
+
+func TestDecodeAlreadyDecoded(t *testing.T) {
+	resp := response("gzip", []byte(payload))
+	resp.Uncompressed = true
+	if err := Decode(resp); err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+
+	got, _ := io.ReadAll(resp.Body)
+	if string(got) != payload {
+		t.Errorf("body = %q, want %q", got, payload)
+	}
+}
+
+func TestDecodeUnsupported(t *testing.T) {
+	if err := Decode(response("zstd", []byte(payload))); !errors.Is(err, ErrUnsupported) {
+		t.Errorf("Decode() error = %v, want %v", err, ErrUnsupported)
+	}
+	if err := Decode(response("gzip", []byte(payload))); err == nil {
+		t.Error("Decode() of a malformed gzip body succeeded")
+	}
+}
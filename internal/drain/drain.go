@@ -0,0 +1,127 @@
+// Package drain coordinates the shutdown of the aggregator clients, refusing
+// new requests and waiting for the ones in flight to finish.
+package drain
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// ErrClosed is returned for requests started after shutdown began
+var ErrClosed = errors.New("client closed")
+
+// Group tracks the requests in flight, from when they are sent until their
+// response body is closed
+type Group struct {
+	mu      sync.Mutex
+	active  int
+	closed  bool
+	drained chan struct{}
+	abort   context.Context
+	cancel  context.CancelFunc
+}
+
+// New creates an open group
+func New() *Group {
+	abort, cancel := context.WithCancel(context.Background())
+	return &Group{abort: abort, cancel: cancel}
+}
+
+// Start registers req as in flight, returning it bound to a context that is
+// cancelled when the group is aborted, and the func to call once it is done.
+// It fails with ErrClosed once shutdown began.
+func (g *Group) Start(req *http.Request) (*http.Request, func(), error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.closed {
+		return nil, nil, ErrClosed
+	}
+	g.active++
+
+	ctx, cancel := context.WithCancel(req.Context())
+	stop := context.AfterFunc(g.abort, cancel)
+
+	var once sync.Once
+	done := func() {
+		once.Do(func() {
+			stop()
+			cancel()
+			g.done()
+		})
+	}
+	return req.WithContext(ctx), done, nil
+}
+
+// Track makes closing the body of resp call done, keeping the request in
+// flight while the body is read. done is called right away without a body.
+func Track(resp *http.Response, done func()) {
+	if resp == nil || resp.Body == nil {
+		done()
+		return
+	}
+	resp.Body = &trackedBody{ReadCloser: resp.Body, done: done}
+}
+
+// Shutdown refuses new requests and waits for those in flight to finish.
+// When ctx is done first, the requests still in flight are cancelled and
+// ctx.Err() is returned.
+func (g *Group) Shutdown(ctx context.Context) error {
+	drained := g.close()
+
+	select {
+	case <-drained:
+		return nil
+	case <-ctx.Done():
+		g.cancel()
+		return ctx.Err()
+	}
+}
+
+// Close refuses new requests, cancels those in flight and waits for them to
+// return
+func (g *Group) Close() {
+	drained := g.close()
+	g.cancel()
+	<-drained
+}
+
+// close marks the group closed and returns the channel closed once no
+// request is in flight
+func (g *Group) close() <-chan struct{} {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if !g.closed {
+		g.closed = true
+		g.drained = make(chan struct{})
+		if g.active == 0 {
+			close(g.drained)
+		}
+	}
+	return g.drained
+}
+
+func (g *Group) done() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.active--
+	if g.closed && g.active == 0 {
+		close(g.drained)
+	}
+}
+
+type trackedBody struct {
+	io.ReadCloser
+	done func()
+}
+
+func (b *trackedBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.done()
+	return err
+}
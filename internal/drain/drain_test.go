@@ -0,0 +1,91 @@
+package drain
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGroup_Shutdown(t *testing.T) {
+	g := New()
+	req, done, err := g.Start(httptestRequest())
+	if err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	resp := &http.Response{Body: io.NopCloser(strings.NewReader("{}"))}
+	Track(resp, done)
+
+	shutdown := make(chan error)
+	go func() { shutdown <- g.Shutdown(context.Background()) }()
+
+	for !g.isClosed() {
+		time.Sleep(time.Millisecond)
+	}
+	if _, _, err := g.Start(httptestRequest()); !errors.Is(err, ErrClosed) {
+		t.Fatalf("Start() after Shutdown error = %v, want ErrClosed", err)
+	}
+	select {
+	case err := <-shutdown:
+		t.Fatalf("Shutdown() returned %v with a body open", err)
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	resp.Body.Close()
+	if err := <-shutdown; err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+	if req.Context().Err() == nil {
+		t.Error("request context not released once done")
+	}
+}
+
+func TestGroup_ShutdownDeadline(t *testing.T) {
+	g := New()
+	req, _, err := g.Start(httptestRequest())
+	if err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := g.Shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Shutdown() error = %v, want DeadlineExceeded", err)
+	}
+	select {
+	case <-req.Context().Done():
+	case <-time.After(time.Second):
+		t.Error("in flight request not cancelled")
+	}
+}
+
+func TestGroup_Close(t *testing.T) {
+	g := New()
+	req, done, err := g.Start(httptestRequest())
+	if err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	go func() {
+		<-req.Context().Done()
+		Track(nil, done)
+	}()
+
+	g.Close()
+	if _, _, err := g.Start(httptestRequest()); !errors.Is(err, ErrClosed) {
+		t.Errorf("Start() after Close error = %v, want ErrClosed", err)
+	}
+}
+
+func httptestRequest() *http.Request {
+	req, _ := http.NewRequest("GET", "http://127.0.0.1:1", nil)
+	return req
+}
+
+func (g *Group) isClosed() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.closed
+}
@@ -0,0 +1,18 @@
+// Package idempotency generates the keys letting a provider or gateway
+// dedupe repeated POST requests.
+package idempotency
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+)
+
+// Header is the request header carrying the key
+const Header = "Idempotency-Key"
+
+// NewKey returns a random 128 bit key, hex encoded
+func NewKey() string {
+	var b [16]byte
+	rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
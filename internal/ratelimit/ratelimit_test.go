@@ -0,0 +1,58 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestLimiter_Reserve(t *testing.T) {
+	now := time.Unix(0, 0)
+	l := New(10, 2)
+	l.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		if wait := l.reserve(); wait != 0 {
+			t.Fatalf("reserve() within burst = %v, want 0", wait)
+		}
+	}
+	if wait := l.reserve(); wait != 100*time.Millisecond {
+		t.Errorf("reserve() past burst = %v, want 100ms", wait)
+	}
+
+	now = now.Add(time.Second)
+	if wait := l.reserve(); wait != 0 {
+		t.Errorf("reserve() after refill = %v, want 0", wait)
+	}
+}
+
+func TestLimiter_WaitCancelled(t *testing.T) {
+	l := New(0.001, 1)
+	if err := l.Wait(context.Background()); err != nil {
+		t.Fatalf("Wait() error = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := l.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Wait() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if l.tokens < -0.01 {
+		t.Errorf("tokens = %v, cancelled reservation not returned", l.tokens)
+	}
+}
+
+func TestNew_NonPositiveRate(t *testing.T) {
+	for _, rate := range []float64{0, -1} {
+		l := New(rate, 1)
+		if l != nil {
+			t.Fatalf("New(%v, 1) = %+v, want nil", rate, l)
+		}
+		for i := 0; i < 3; i++ {
+			if err := l.Wait(context.Background()); err != nil {
+				t.Errorf("nil Limiter.Wait() error = %v", err)
+			}
+		}
+	}
+}
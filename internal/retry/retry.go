@@ -0,0 +1,94 @@
+// Package retry implements the backoff of the aggregator clients retrying
+// rate limited requests, honoring the Retry-After header of the provider.
+package retry
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Policy retries a rate limited request up to MaxRetries times, waiting as
+// long as the Retry-After header asks or BaseDelay doubled on every attempt
+// otherwise. A wait longer than MaxDelay gives up instead of retrying.
+type Policy struct {
+	MaxRetries int
+	BaseDelay  time.Duration
+	MaxDelay   time.Duration
+}
+
+// Retryable reports whether resp is rate limited and worth retrying
+func Retryable(resp *http.Response) bool {
+	return resp.StatusCode == http.StatusTooManyRequests
+}
+
+// Delay returns how long to wait before retrying resp after attempt
+// previous retries, false when the wait exceeds MaxDelay
+func (p Policy) Delay(attempt int, resp *http.Response, now time.Time) (time.Duration, bool) {
+	delay, ok := ParseRetryAfter(resp.Header.Get("Retry-After"), now)
+	if !ok {
+		delay = p.BaseDelay
+		for i := 0; i < attempt && delay < p.MaxDelay; i++ {
+			delay *= 2
+		}
+		delay = min(delay, p.MaxDelay)
+	}
+
+	return delay, delay <= p.MaxDelay
+}
+
+// ParseRetryAfter parses a Retry-After header, in delay seconds or as an
+// HTTP date, into the duration to wait from now
+func ParseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+	return max(date.Sub(now), 0), true
+}
+
+// Sleep waits for d, returning the context error early when ctx is done
+func Sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Rewind returns a copy of req to send again, false when its body can't be
+// read a second time
+func Rewind(req *http.Request) (*http.Request, bool) {
+	retryReq := req.Clone(req.Context())
+	if req.Body == nil || req.Body == http.NoBody {
+		return retryReq, true
+	}
+	if req.GetBody == nil {
+		return nil, false
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, false
+	}
+	retryReq.Body = body
+	return retryReq, true
+}
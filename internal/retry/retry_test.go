@@ -0,0 +1,81 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value  string
+		want   time.Duration
+		wantOk bool
+	}{
+		{"", 0, false},
+		{"3", 3 * time.Second, true},
+		{" 0 ", 0, true},
+		{"-1", 0, false},
+		{"Tue, 01 Oct 2024 12:00:30 GMT", 30 * time.Second, true},
+		{"Tue, 01 Oct 2024 11:59:00 GMT", 0, true},
+		{"soon", 0, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := ParseRetryAfter(tt.value, now)
+		if got != tt.want || ok != tt.wantOk {
+			t.Errorf("ParseRetryAfter(%q) = %v, %v, want %v, %v", tt.value, got, ok, tt.want, tt.wantOk)
+		}
+	}
+}
+
+func TestPolicy_Delay(t *testing.T) {
+	policy := Policy{MaxRetries: 5, BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+	resp := &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{}}
+
+	for attempt, want := range []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond, time.Second} {
+		if got, ok := policy.Delay(attempt, resp, time.Now()); got != want || !ok {
+			t.Errorf("Delay(%d) = %v, %v, want %v", attempt, got, ok, want)
+		}
+	}
+
+	resp.Header.Set("Retry-After", "1")
+	if got, ok := policy.Delay(0, resp, time.Now()); got != time.Second || !ok {
+		t.Errorf("Delay() with Retry-After = %v, %v, want 1s", got, ok)
+	}
+	resp.Header.Set("Retry-After", "60")
+	if _, ok := policy.Delay(0, resp, time.Now()); ok {
+		t.Error("Delay() retries past MaxDelay")
+	}
+}
+
+func TestSleepCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := Sleep(ctx, time.Hour); !errors.Is(err, context.Canceled) {
+		t.Errorf("Sleep() error = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestRewind(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodPost, "http://example.com", strings.NewReader("body"))
+	io.ReadAll(req.Body)
+
+	retryReq, ok := Rewind(req)
+	if !ok {
+		t.Fatal("Rewind() failed on a replayable body")
+	}
+	if body, _ := io.ReadAll(retryReq.Body); string(body) != "body" {
+		t.Errorf("rewound body = %q", body)
+	}
+
+	req.GetBody = nil
+	if _, ok := Rewind(req); ok {
+		t.Error("Rewind() succeeded on a body that can't be replayed")
+	}
+}
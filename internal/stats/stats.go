@@ -0,0 +1,74 @@
+// Package stats tracks request counts and latency per endpoint, giving
+// callers a live signal of how fast and reliable a provider currently is.
+package stats
+
+import (
+	"sync"
+	"time"
+)
+
+// Alpha is the weight of the latest observation in the latency moving
+// average, higher values follow changes faster
+const Alpha = 0.2
+
+// Endpoint holds the observations of a single endpoint
+type Endpoint struct {
+	Requests int64 // requests sent
+	Errors   int64 // requests without a response or with a 4xx/5xx status
+	Rejected int64 // calls failed fast without being sent, e.g. by an open circuit breaker
+	// LatencyEMA is the exponential moving average of the request latency,
+	// the first observation seeds it
+	LatencyEMA time.Duration
+}
+
+// Tracker accumulates observations per endpoint, it is safe for concurrent use
+type Tracker struct {
+	mu        sync.Mutex
+	endpoints map[string]Endpoint
+}
+
+// New creates an empty tracker
+func New() *Tracker {
+	return &Tracker{endpoints: make(map[string]Endpoint)}
+}
+
+// Observe records a request to endpoint that took dur
+func (t *Tracker) Observe(endpoint string, dur time.Duration, failed bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	e := t.endpoints[endpoint]
+	if e.Requests == 0 {
+		e.LatencyEMA = dur
+	} else {
+		e.LatencyEMA += time.Duration(Alpha * float64(dur-e.LatencyEMA))
+	}
+	e.Requests++
+	if failed {
+		e.Errors++
+	}
+	t.endpoints[endpoint] = e
+}
+
+// Reject records a call to endpoint that failed before being sent, leaving
+// the request count and latency alone
+func (t *Tracker) Reject(endpoint string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	e := t.endpoints[endpoint]
+	e.Rejected++
+	t.endpoints[endpoint] = e
+}
+
+// Snapshot returns a copy of the observations keyed by endpoint
+func (t *Tracker) Snapshot() map[string]Endpoint {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	snapshot := make(map[string]Endpoint, len(t.endpoints))
+	for name, e := range t.endpoints {
+		snapshot[name] = e
+	}
+	return snapshot
+}
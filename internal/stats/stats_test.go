@@ -0,0 +1,30 @@
+package stats
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTracker(t *testing.T) {
+	tracker := New()
+	tracker.Observe("quote", 100*time.Millisecond, false)
+	tracker.Observe("quote", 200*time.Millisecond, true)
+	tracker.Observe("assemble", 50*time.Millisecond, false)
+
+	snapshot := tracker.Snapshot()
+	quote := snapshot["quote"]
+	if quote.Requests != 2 || quote.Errors != 1 {
+		t.Errorf("quote = %+v, want 2 requests and 1 error", quote)
+	}
+	if quote.LatencyEMA != 120*time.Millisecond {
+		t.Errorf("quote LatencyEMA = %v, want 120ms", quote.LatencyEMA)
+	}
+	if assemble := snapshot["assemble"]; assemble.Requests != 1 || assemble.LatencyEMA != 50*time.Millisecond {
+		t.Errorf("assemble = %+v", assemble)
+	}
+
+	snapshot["quote"] = Endpoint{}
+	if tracker.Snapshot()["quote"].Requests != 2 {
+		t.Error("Snapshot() shares state with the tracker")
+	}
+}
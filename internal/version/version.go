@@ -0,0 +1,8 @@
+// Package version identifies this library in outbound requests.
+package version
+
+// Version is the version of the library
+const Version = "0.1.0"
+
+// UserAgent is the default User-Agent of both clients
+const UserAgent = "dex-swap-api-helper/" + Version + " (+https://github.com/ThreeAndTwo/dex-swap-api-helper)"
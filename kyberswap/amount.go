@@ -0,0 +1,31 @@
+package kyberswap
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+)
+
+// GetRoutesAmount fetches routes for token swap of amountIn, in the smallest
+// unit of tokenIn
+func (c *KyberSwapClient) GetRoutesAmount(tokenIn, tokenOut string, amountIn *big.Int) (*RouteResponse, error) {
+	if amountIn == nil || amountIn.Sign() <= 0 {
+		return nil, fmt.Errorf("amountIn: %w: must be positive, got %v", ErrInvalidAmount, amountIn)
+	}
+	return c.GetRoutesCtx(context.Background(), tokenIn, tokenOut, amountIn.String(), nil)
+}
+
+// validateAmount checks amount is a raw integer amount, catching human
+// decimal values like "1.5" before KyberSwap rejects them
+func validateAmount(amount string) error {
+	if amount == "" {
+		return fmt.Errorf("%w: empty", ErrInvalidAmount)
+	}
+	for _, r := range amount {
+		if r < '0' || r > '9' {
+			return fmt.Errorf("%w: %q must contain only digits, the amount in the smallest token unit "+
+				"(e.g. wei), use units.ParseAmount to convert decimal amounts", ErrInvalidAmount, amount)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package kyberswap
+
+import (
+	"errors"
+	"math/big"
+	"net/http"
+	"testing"
+)
+
+func TestGetRoutesAmount(t *testing.T) {
+	var amountIn string
+	server, client := NewTestServer(TestHandlers{
+		Routes: func(w http.ResponseWriter, r *http.Request) {
+			amountIn = r.URL.Query().Get("amountIn")
+			w.Write([]byte(cannedRoutesResponse))
+		},
+	})
+	defer server.Close()
+
+	amount, _ := new(big.Int).SetString("1500000000000000000", 10)
+	if _, err := client.GetRoutesAmount(USDT, sUSDe, amount); err != nil {
+		t.Fatalf("GetRoutesAmount() error = %v", err)
+	}
+	if amountIn != "1500000000000000000" {
+		t.Errorf("amountIn = %s", amountIn)
+	}
+
+	for _, amount := range []*big.Int{nil, big.NewInt(0), big.NewInt(-1)} {
+		if _, err := client.GetRoutesAmount(USDT, sUSDe, amount); !errors.Is(err, ErrInvalidAmount) {
+			t.Errorf("GetRoutesAmount(%v) error = %v, want ErrInvalidAmount", amount, err)
+		}
+	}
+}
+
+func TestGetRoutes_InvalidAmount(t *testing.T) {
+	client := NewClient("http://127.0.0.1:1", chain)
+	for _, amount := range []string{"", "1.5", "-1", "1e18", "0x10", " 1"} {
+		if _, err := client.GetRoutes(USDT, sUSDe, amount); !errors.Is(err, ErrInvalidAmount) {
+			t.Errorf("GetRoutes(%q) error = %v, want ErrInvalidAmount", amount, err)
+		}
+	}
+}
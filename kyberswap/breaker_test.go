@@ -0,0 +1,46 @@
+package kyberswap
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestWithCircuitBreaker(t *testing.T) {
+	var calls int
+	recorder := &testRecorder{}
+	server, client := NewTestServer(TestHandlers{
+		Routes: func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			if calls == 1 {
+				w.WriteHeader(http.StatusBadGateway)
+				return
+			}
+			w.Write([]byte(cannedRoutesResponse))
+		},
+	}, WithCircuitBreaker(1, 50*time.Millisecond), WithMetrics(recorder))
+	defer server.Close()
+
+	client.GetRoutes(USDT, sUSDe, "1000000")
+	if _, err := client.GetRoutes(USDT, sUSDe, "1000000"); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("GetRoutes() error = %v, want ErrCircuitOpen", err)
+	}
+	if last := len(recorder.errs) - 1; !errors.Is(recorder.errs[last], ErrCircuitOpen) || recorder.statusCodes[last] != 0 {
+		t.Errorf("observed errors = %v, status codes = %v, want the rejected call last", recorder.errs, recorder.statusCodes)
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	if _, err := client.GetRoutes(USDT, sUSDe, "1000000"); err != nil {
+		t.Fatalf("GetRoutes() probe error = %v", err)
+	}
+	if _, err := client.GetRoutes(USDT, sUSDe, "1000000"); err != nil {
+		t.Fatalf("GetRoutes() after recovery error = %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("server called %d times, want 3", calls)
+	}
+	if stats := client.Stats()[EndpointRoutes]; stats.Requests != 3 || stats.Rejected != 1 {
+		t.Errorf("Stats() = %+v, want 3 requests and 1 rejected", stats)
+	}
+}
@@ -0,0 +1,83 @@
+package kyberswap
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// buildRequestBody runs BuildRouteCtx against a test server and returns the
+// decoded JSON body it received
+func buildRequestBody(t *testing.T, opts *BuildRouteOptions) map[string]interface{} {
+	t.Helper()
+
+	var body map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&body)
+		w.Write([]byte(`{"code":0,"data":{}}`))
+	}))
+	defer server.Close()
+
+	sender := "0xd46B96d15ffF9b2B17e9c788086f3159bD0e8355"
+	client := NewClient(server.URL, chain)
+	if _, err := client.BuildRouteCtx(context.Background(), RouteSummary{}, sender, sender, opts); err != nil {
+		t.Fatalf("BuildRouteCtx() error = %v", err)
+	}
+	return body
+}
+
+func TestBuildRouteOptions_Permit(t *testing.T) {
+	if body := buildRequestBody(t, nil); body["permit"] != nil {
+		t.Errorf("permit sent without option: %v", body["permit"])
+	}
+
+	body := buildRequestBody(t, &BuildRouteOptions{Permit: "0xabcdef"})
+	if body["permit"] != "0xabcdef" {
+		t.Errorf("permit = %v, want 0xabcdef", body["permit"])
+	}
+}
+
+func TestBuildRouteOptions_SkipSimulateTx(t *testing.T) {
+	if body := buildRequestBody(t, nil); body["skipSimulateTx"] != nil {
+		t.Errorf("skipSimulateTx sent without option: %v", body["skipSimulateTx"])
+	}
+
+	body := buildRequestBody(t, &BuildRouteOptions{SkipSimulateTx: true})
+	if body["skipSimulateTx"] != true {
+		t.Errorf("skipSimulateTx = %v, want true", body["skipSimulateTx"])
+	}
+}
+
+func TestBuildRouteOptions_Source(t *testing.T) {
+	if body := buildRequestBody(t, nil); body["source"] != nil {
+		t.Errorf("source sent without option: %v", body["source"])
+	}
+
+	body := buildRequestBody(t, &BuildRouteOptions{Source: "dex-swap-api-helper"})
+	if body["source"] != "dex-swap-api-helper" {
+		t.Errorf("source = %v, want dex-swap-api-helper", body["source"])
+	}
+}
+
+func TestBuildRouteOptions_IdempotencyKey(t *testing.T) {
+	var keys []string
+	server, client := NewTestServer(TestHandlers{
+		Build: func(w http.ResponseWriter, r *http.Request) {
+			keys = append(keys, r.Header.Get("Idempotency-Key"))
+			w.Write([]byte(cannedBuildResponse))
+		},
+	})
+	defer server.Close()
+
+	sender := "0xd46B96d15ffF9b2B17e9c788086f3159bD0e8355"
+	for _, opts := range []*BuildRouteOptions{{IdempotencyKey: "swap-42"}, nil, nil} {
+		if _, err := client.BuildRouteCtx(context.Background(), RouteSummary{}, sender, sender, opts); err != nil {
+			t.Fatalf("BuildRouteCtx() error = %v", err)
+		}
+	}
+	if len(keys) != 3 || keys[0] != "swap-42" || keys[1] == "" || keys[1] == keys[2] {
+		t.Errorf("Idempotency-Key = %q, want swap-42 then distinct generated keys", keys)
+	}
+}
@@ -0,0 +1,59 @@
+package kyberswap
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+)
+
+// RawCapture receives the exact request and response bodies of a call to
+// endpoint, e.g. to persist the payloads of a failed swap. respBody is nil
+// when no response was received.
+type RawCapture func(endpoint string, reqBody, respBody []byte)
+
+// WithRawCapture calls capture with the raw payloads of every request
+func WithRawCapture(capture RawCapture) Option {
+	return func(c *KyberSwapClient) {
+		c.capture = capture
+	}
+}
+
+// requestBody returns a copy of the body of req without consuming it
+func requestBody(req *http.Request) []byte {
+	if req.GetBody == nil {
+		return nil
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil
+	}
+	defer body.Close()
+
+	reqBody, _ := io.ReadAll(body)
+	return reqBody
+}
+
+// captureResponse reads the body of resp and hands it to capture, replacing
+// it with a reader replaying the same bytes and read error
+func captureResponse(capture RawCapture, endpoint string, reqBody []byte, resp *http.Response) {
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	var replay io.Reader = bytes.NewReader(respBody)
+	if err != nil {
+		replay = io.MultiReader(replay, errReader{err})
+	}
+	resp.Body = io.NopCloser(replay)
+
+	capture(endpoint, reqBody, respBody)
+}
+
+// errReader is a reader failing with err
+type errReader struct {
+	err error
+}
+
+func (r errReader) Read([]byte) (int, error) {
+	return 0, r.err
+}
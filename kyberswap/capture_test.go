@@ -0,0 +1,40 @@
+package kyberswap
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+type capturedCall struct {
+	endpoint string
+	reqBody  string
+	respBody string
+}
+
+func TestWithRawCapture(t *testing.T) {
+	var calls []capturedCall
+	server, client := NewTestServer(TestHandlers{}, WithRawCapture(func(endpoint string, reqBody, respBody []byte) {
+		calls = append(calls, capturedCall{endpoint, string(reqBody), string(respBody)})
+	}))
+	defer server.Close()
+
+	sender := "0xd46B96d15ffF9b2B17e9c788086f3159bD0e8355"
+	resp, err := client.Swap(context.Background(), USDT, sUSDe, "1000000", sender, sender, nil)
+	if err != nil {
+		t.Fatalf("Swap() error = %v", err)
+	}
+	if resp.Data.Data != "0xe21fd0e9" {
+		t.Errorf("response decoded after capture = %+v", resp.Data)
+	}
+
+	if len(calls) != 2 {
+		t.Fatalf("captured %d calls, want 2", len(calls))
+	}
+	if calls[0].endpoint != EndpointRoutes || calls[0].reqBody != "" || calls[0].respBody != cannedRoutesResponse {
+		t.Errorf("routes capture = %+v", calls[0])
+	}
+	if calls[1].endpoint != EndpointBuild || !strings.Contains(calls[1].reqBody, sender) || calls[1].respBody != cannedBuildResponse {
+		t.Errorf("build capture = %+v", calls[1])
+	}
+}
@@ -0,0 +1,83 @@
+package kyberswap
+
+import (
+	"fmt"
+
+	"github.com/ThreeAndTwo/dex-swap-api-helper/chains"
+)
+
+// supportedChains maps the chain names accepted by the KyberSwap aggregator
+// API to their EVM chain IDs.
+var supportedChains = map[string]chains.ChainID{
+	"ethereum":      chains.Ethereum,
+	"optimism":      chains.Optimism,
+	"bsc":           chains.BSC,
+	"unichain":      chains.Unichain,
+	"polygon":       chains.Polygon,
+	"sonic":         chains.Sonic,
+	"fantom":        chains.Fantom,
+	"zksync":        chains.ZkSync,
+	"polygon-zkevm": chains.PolygonZkEVM,
+	"ronin":         chains.Ronin,
+	"mantle":        chains.Mantle,
+	"base":          chains.Base,
+	"arbitrum":      chains.Arbitrum,
+	"avalanche":     chains.Avalanche,
+	"linea":         chains.Linea,
+	"berachain":     chains.Berachain,
+	"blast":         chains.Blast,
+	"scroll":        chains.Scroll,
+}
+
+// IsSupportedChain reports whether chain is a chain name known to KyberSwap
+func IsSupportedChain(chain string) bool {
+	_, ok := supportedChains[chain]
+	return ok
+}
+
+// ChainID returns the EVM chain ID for a KyberSwap chain name
+func ChainID(chain string) (int64, error) {
+	id, ok := supportedChains[chain]
+	if !ok {
+		return 0, fmt.Errorf("unsupported chain: %q", chain)
+	}
+	return int64(id), nil
+}
+
+// ChainName returns the KyberSwap chain name, the path segment of the API,
+// for a chain ID
+func ChainName(id chains.ChainID) (string, error) {
+	for name, chainID := range supportedChains {
+		if chainID == id {
+			return name, nil
+		}
+	}
+	return "", fmt.Errorf("unsupported chain: %s", id)
+}
+
+// NewClientForChain creates a new KyberSwap client routing on the chain id,
+// returning an error if KyberSwap doesn't support it
+func NewClientForChain(baseURL string, id chains.ChainID) (*KyberSwapClient, error) {
+	name, err := ChainName(id)
+	if err != nil {
+		return nil, err
+	}
+	return NewClient(baseURL, name), nil
+}
+
+// WithChainID sets the chain the client routes on by chain ID
+func WithChainID(id chains.ChainID) Option {
+	return func(c *KyberSwapClient) {
+		name, err := ChainName(id)
+		if err != nil {
+			// left for NewClientWithOptions to reject
+			name = id.String()
+		}
+		c.chain.Store(&name)
+	}
+}
+
+// ChainID returns the ID of the chain the client is routing on
+func (c *KyberSwapClient) ChainID() chains.ChainID {
+	return supportedChains[c.Chain()]
+}
@@ -0,0 +1,99 @@
+package kyberswap
+
+import (
+	"testing"
+
+	"github.com/ThreeAndTwo/dex-swap-api-helper/chains"
+)
+
+func TestIsSupportedChain(t *testing.T) {
+	tests := []struct {
+		name  string
+		chain string
+		want  bool
+	}{
+		{name: "ethereum", chain: "ethereum", want: true},
+		{name: "arbitrum", chain: "arbitrum", want: true},
+		{name: "misspelled", chain: "etherium", want: false},
+		{name: "empty", chain: "", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsSupportedChain(tt.chain); got != tt.want {
+				t.Errorf("IsSupportedChain(%q) = %v, want %v", tt.chain, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewClientChecked(t *testing.T) {
+	tests := []struct {
+		name    string
+		chain   string
+		wantErr bool
+	}{
+		{name: "known chain", chain: "base", wantErr: false},
+		{name: "default chain", chain: "", wantErr: false},
+		{name: "unknown chain", chain: "etherium", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewClientChecked("", tt.chain)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewClientChecked() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestKyberSwapClient_SetChain(t *testing.T) {
+	client := NewClient("", chain).WithTimeout(0)
+	httpClient := client.httpClient
+
+	if err := client.SetChain("arbitrum"); err != nil {
+		t.Fatalf("SetChain() error = %v", err)
+	}
+	if got := client.chainURL(); got != _baseURL+"/arbitrum" {
+		t.Errorf("chainURL() = %s", got)
+	}
+	if client.httpClient != httpClient {
+		t.Errorf("SetChain() replaced the http client")
+	}
+
+	if err := client.SetChain("etherium"); err == nil {
+		t.Errorf("SetChain() expected error for unknown chain")
+	}
+	if got := client.Chain(); got != "arbitrum" {
+		t.Errorf("Chain() = %s, want arbitrum", got)
+	}
+}
+
+func TestChainIDMapping(t *testing.T) {
+	for name, id := range supportedChains {
+		got, err := ChainName(id)
+		if err != nil || got != name {
+			t.Errorf("ChainName(%d) = %q, %v, want %q", id, got, err, name)
+		}
+	}
+
+	if _, err := ChainName(chains.Mode); err == nil {
+		t.Error("ChainName() accepted a chain KyberSwap doesn't support")
+	}
+
+	client, err := NewClientForChain("", chains.Arbitrum)
+	if err != nil {
+		t.Fatalf("NewClientForChain() error = %v", err)
+	}
+	if client.Chain() != "arbitrum" || client.ChainID() != chains.Arbitrum {
+		t.Errorf("client chain = %s (%d), want arbitrum", client.Chain(), client.ChainID())
+	}
+
+	if client, err = NewClientWithOptions(WithChainID(chains.Base)); err != nil || client.Chain() != "base" {
+		t.Errorf("NewClientWithOptions(WithChainID(Base)) = %v, %v", client, err)
+	}
+	if _, err := NewClientWithOptions(WithChainID(chains.ChainID(999999))); err == nil {
+		t.Error("NewClientWithOptions() accepted an unknown chain id")
+	}
+}
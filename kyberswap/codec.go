@@ -0,0 +1,20 @@
+package kyberswap
+
+import "github.com/ThreeAndTwo/dex-swap-api-helper/internal/codec"
+
+// Codec marshals the request bodies and unmarshals the response bodies of the
+// client. It lets a faster JSON library such as json-iterator or Sonic
+// replace encoding/json, the implementation must honour the json.Marshaler
+// and json.Unmarshaler methods of the request and response types.
+type Codec = codec.Codec
+
+// WithJSONCodec sets the codec encoding requests and decoding responses,
+// encoding/json by default. Error bodies are still decoded with
+// encoding/json, they are small and rarely on the hot path.
+func WithJSONCodec(jsonCodec Codec) Option {
+	return func(c *KyberSwapClient) {
+		if jsonCodec != nil {
+			c.codec = jsonCodec
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package kyberswap
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// countingCodec wraps encoding/json, counting the calls
+type countingCodec struct {
+	marshals, unmarshals int
+}
+
+func (c *countingCodec) Marshal(v interface{}) ([]byte, error) {
+	c.marshals++
+	return json.Marshal(v)
+}
+
+func (c *countingCodec) Unmarshal(data []byte, v interface{}) error {
+	c.unmarshals++
+	return json.Unmarshal(data, v)
+}
+
+func TestWithJSONCodec(t *testing.T) {
+	jsonCodec := &countingCodec{}
+	server, client := NewTestServer(TestHandlers{}, WithJSONCodec(jsonCodec))
+	defer server.Close()
+
+	routes, err := client.GetRoutes(USDT, sUSDe, "1000000")
+	if err != nil {
+		t.Fatalf("GetRoutes() error = %v", err)
+	}
+	sender := "0xd46B96d15ffF9b2B17e9c788086f3159bD0e8355"
+	if _, err := client.BuildRoute(routes.Data.RouteSummary, sender, sender); err != nil {
+		t.Fatalf("BuildRoute() error = %v", err)
+	}
+	// the route summary is decoded with the codec too
+	if jsonCodec.marshals != 1 || jsonCodec.unmarshals != 3 {
+		t.Errorf("codec marshals = %d, unmarshals = %d, want 1 and 3", jsonCodec.marshals, jsonCodec.unmarshals)
+	}
+
+	summary := routes.Data.RouteSummary
+	summary.raw = nil
+	if _, err := client.BuildRoute(summary, sender, sender); err != nil {
+		t.Fatalf("BuildRoute() error = %v", err)
+	}
+	// a summary without raw bytes is encoded with the codec
+	if jsonCodec.marshals != 3 {
+		t.Errorf("codec marshals = %d, want 3", jsonCodec.marshals)
+	}
+}
@@ -0,0 +1,101 @@
+package kyberswap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+)
+
+// ChainQuery is the route query of one chain compared by BestAcrossChains.
+// The token addresses are those of the asset on that chain.
+type ChainQuery struct {
+	Chain    string
+	TokenIn  string
+	TokenOut string
+	AmountIn string
+	Options  *GetRoutesOptions
+}
+
+// ChainRoute is the outcome of a ChainQuery, Err is set when the chain failed
+type ChainRoute struct {
+	Chain  string
+	Route  *RouteResponse
+	NetUsd float64 // output value minus gas cost in USD
+	Err    error
+}
+
+// BestAcrossChains fetches the routes of queries concurrently, one goroutine
+// per chain sharing ctx and its deadline, and returns the route with the
+// highest net USD output along with every outcome in the order of queries.
+// A failing chain doesn't stop the others, an error is only returned when
+// no chain produced a route.
+func (c *KyberSwapClient) BestAcrossChains(ctx context.Context, queries []ChainQuery) (*ChainRoute, []ChainRoute, error) {
+	results := make([]ChainRoute, len(queries))
+
+	var wg sync.WaitGroup
+	for i, query := range queries {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = c.routeOnChain(ctx, query)
+		}()
+	}
+	wg.Wait()
+
+	var best *ChainRoute
+	var errs []error
+	for i := range results {
+		if results[i].Err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", results[i].Chain, results[i].Err))
+			continue
+		}
+		if best == nil || results[i].NetUsd > best.NetUsd {
+			best = &results[i]
+		}
+	}
+
+	if best == nil {
+		return nil, results, fmt.Errorf("no route on any chain: %w", errors.Join(errs...))
+	}
+	return best, results, nil
+}
+
+// routeOnChain fetches the route of query with a copy of the client switched
+// to the query chain, leaving c untouched for the concurrent calls
+func (c *KyberSwapClient) routeOnChain(ctx context.Context, query ChainQuery) ChainRoute {
+	result := ChainRoute{Chain: query.Chain}
+
+	if !IsSupportedChain(query.Chain) {
+		result.Err = fmt.Errorf("unsupported chain: %q", query.Chain)
+		return result
+	}
+	chainClient := *c
+	chainClient.chain = newChain(query.Chain)
+
+	result.Route, result.Err = chainClient.GetRoutesCtx(ctx, query.TokenIn, query.TokenOut, query.AmountIn, query.Options)
+	if result.Err != nil {
+		return result
+	}
+
+	result.NetUsd, result.Err = netUsd(result.Route.Data.RouteSummary)
+	return result
+}
+
+// netUsd returns the output value of a route minus its gas cost in USD
+func netUsd(summary RouteSummary) (float64, error) {
+	out, err := strconv.ParseFloat(summary.AmountOutUsd, 64)
+	if err != nil {
+		return 0, fmt.Errorf("error parsing amountOutUsd: %w", err)
+	}
+
+	var gas float64
+	if summary.GasUsd != "" {
+		gas, err = summary.GasUSDFloat()
+		if err != nil {
+			return 0, err
+		}
+	}
+	return out - gas, nil
+}
@@ -0,0 +1,73 @@
+package kyberswap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestBestAcrossChains(t *testing.T) {
+	server, client := NewTestServer(TestHandlers{
+		Routes: func(w http.ResponseWriter, r *http.Request) {
+			switch {
+			case strings.HasPrefix(r.URL.Path, "/ethereum/"):
+				fmt.Fprint(w, `{"code":0,"data":{"routeSummary":{"amountOutUsd":"1000","gasUsd":"20"}}}`)
+			case strings.HasPrefix(r.URL.Path, "/arbitrum/"):
+				fmt.Fprint(w, `{"code":0,"data":{"routeSummary":{"amountOutUsd":"995","gasUsd":"0.1"}}}`)
+			default:
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprint(w, `{"code":4008,"message":"route not found"}`)
+			}
+		},
+	})
+	defer server.Close()
+
+	queries := []ChainQuery{
+		{Chain: "ethereum", TokenIn: USDT, TokenOut: sUSDe, AmountIn: "1000000000"},
+		{Chain: "arbitrum", TokenIn: USDT, TokenOut: sUSDe, AmountIn: "1000000000"},
+		{Chain: "base", TokenIn: USDT, TokenOut: sUSDe, AmountIn: "1000000000"},
+		{Chain: "unknown", TokenIn: USDT, TokenOut: sUSDe, AmountIn: "1000000000"},
+	}
+	best, results, err := client.BestAcrossChains(context.Background(), queries)
+	if err != nil {
+		t.Fatalf("BestAcrossChains() error = %v", err)
+	}
+
+	if best.Chain != "arbitrum" || best.NetUsd != 994.9 {
+		t.Errorf("best = %s with %v, want arbitrum with 994.9", best.Chain, best.NetUsd)
+	}
+	if len(results) != len(queries) {
+		t.Fatalf("got %d results, want %d", len(results), len(queries))
+	}
+	for i, result := range results {
+		if result.Chain != queries[i].Chain {
+			t.Errorf("results[%d].Chain = %s, want %s", i, result.Chain, queries[i].Chain)
+		}
+	}
+	if results[2].Err == nil || results[3].Err == nil {
+		t.Errorf("failing chains have no error: %v, %v", results[2].Err, results[3].Err)
+	}
+	if client.Chain() != chain {
+		t.Errorf("client chain changed to %s", client.Chain())
+	}
+}
+
+func TestBestAcrossChains_AllFail(t *testing.T) {
+	server, client := NewTestServer(TestHandlers{
+		Routes: func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"code":4008,"message":"route not found"}`)
+		},
+	})
+	defer server.Close()
+
+	_, _, err := client.BestAcrossChains(context.Background(), []ChainQuery{
+		{Chain: "ethereum", TokenIn: USDT, TokenOut: sUSDe, AmountIn: "1"},
+	})
+	if !errors.Is(err, ErrNoRoute) {
+		t.Fatalf("BestAcrossChains() error = %v, want ErrNoRoute", err)
+	}
+}
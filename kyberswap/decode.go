@@ -0,0 +1,51 @@
+package kyberswap
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ThreeAndTwo/dex-swap-api-helper/internal/compress"
+)
+
+var (
+	// ErrEmptyResponse is returned when a successful response has no body
+	ErrEmptyResponse = errors.New("kyberswap: empty response body")
+	// ErrTruncatedResponse is returned when a response body ends mid JSON document
+	ErrTruncatedResponse = errors.New("kyberswap: truncated response body")
+	// ErrUnsupportedEncoding is returned for a response compressed with an
+	// encoding the client cannot decode
+	ErrUnsupportedEncoding = compress.ErrUnsupported
+)
+
+// codecUnmarshaler is implemented by the responses holding values with
+// custom JSON decoding, so those are decoded with the client codec too
+// rather than with encoding/json from their UnmarshalJSON method
+type codecUnmarshaler interface {
+	unmarshalWith(jsonCodec Codec, data []byte) error
+}
+
+// decodeBody decodes the JSON body of a response into v with the codec of the
+// client, reporting empty and truncated bodies explicitly instead of as bare
+// JSON syntax errors
+func (c *KyberSwapClient) decodeBody(statusCode int, body []byte, v interface{}) error {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return fmt.Errorf("%w (status %d)", ErrEmptyResponse, statusCode)
+	}
+
+	var err error
+	if u, ok := v.(codecUnmarshaler); ok {
+		err = u.unmarshalWith(c.codec, body)
+	} else {
+		err = c.codec.Unmarshal(body, v)
+	}
+	if err != nil {
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			return fmt.Errorf("%w (status %d, %d bytes): %w", ErrTruncatedResponse, statusCode, len(body), err)
+		}
+		return err
+	}
+
+	return nil
+}
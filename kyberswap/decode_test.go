@@ -0,0 +1,71 @@
+package kyberswap
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+)
+
+func TestGetRoutes_BadBodies(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		wantErr error
+	}{
+		{name: "empty", body: "", wantErr: ErrEmptyResponse},
+		{name: "truncated", body: `{"code":0,"data":{"routeSummary":{"tokenIn":"0xdac1`, wantErr: ErrTruncatedResponse},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, client := NewTestServer(TestHandlers{
+				Routes: func(w http.ResponseWriter, r *http.Request) {
+					w.Write([]byte(tt.body))
+				},
+			})
+			defer server.Close()
+
+			if _, err := client.GetRoutes(USDT, sUSDe, "1000000"); !errors.Is(err, tt.wantErr) {
+				t.Errorf("GetRoutes() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGetRoutes_CompressedBody(t *testing.T) {
+	var encoded bytes.Buffer
+	bw := brotli.NewWriter(&encoded)
+	bw.Write([]byte(cannedRoutesResponse))
+	bw.Close()
+
+	server, client := NewTestServer(TestHandlers{
+		Routes: func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Encoding", "br")
+			w.Write(encoded.Bytes())
+		},
+	})
+	defer server.Close()
+
+	got, err := client.GetRoutes(USDT, sUSDe, "1000000")
+	if err != nil {
+		t.Fatalf("GetRoutes() error = %v", err)
+	}
+	if got.Data.RouteSummary.AmountOut != "874518826958614826" {
+		t.Errorf("GetRoutes() amountOut = %q", got.Data.RouteSummary.AmountOut)
+	}
+
+	server, client = NewTestServer(TestHandlers{
+		Routes: func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Encoding", "zstd")
+			w.Write([]byte(cannedRoutesResponse))
+		},
+	})
+	defer server.Close()
+
+	if _, err := client.GetRoutes(USDT, sUSDe, "1000000"); !errors.Is(err, ErrUnsupportedEncoding) {
+		t.Errorf("GetRoutes() error = %v, want %v", err, ErrUnsupportedEncoding)
+	}
+}
@@ -0,0 +1,112 @@
+package kyberswap
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// _settingsURL is the KyberSwap settings API listing the DEXes per chain
+const _settingsURL = "https://ks-setting.kyberswap.com"
+
+// _dexesPageSize is the number of DEXes requested per page
+const _dexesPageSize = 100
+
+// Dex is a liquidity source KyberSwap routes through
+type Dex struct {
+	ID int `json:"id"`
+	// DexID identifies the DEX in GetRoutesOptions IncludedSources and
+	// ExcludedSources, e.g. "uniswapv3"
+	DexID   string `json:"dexId"`
+	Name    string `json:"name"`
+	LogoURL string `json:"logoURL"`
+}
+
+// dexesResponse represents a page of the DEX list
+type dexesResponse struct {
+	Code    int64  `json:"code"`
+	Message string `json:"message"`
+	Data    struct {
+		Dexes      []Dex `json:"dexes"`
+		Pagination struct {
+			TotalItems int `json:"totalItems"`
+		} `json:"pagination"`
+	} `json:"data"`
+}
+
+// WithSettingsURL sets the KyberSwap settings API base URL used by
+// GetSupportedDexes, an empty URL keeps the default
+func WithSettingsURL(settingsURL string) Option {
+	return func(c *KyberSwapClient) {
+		if settingsURL != "" {
+			c.settingsURL = settingsURL
+		}
+	}
+}
+
+// GetSupportedDexes lists the DEXes KyberSwap has enabled on chain, e.g. to
+// offer the sources of GetRoutesOptions without hardcoding their ids
+// /api/v1/dexes of the settings API
+func (c *KyberSwapClient) GetSupportedDexes(chain string) ([]Dex, error) {
+	if !IsSupportedChain(chain) {
+		return nil, fmt.Errorf("unsupported chain: %q", chain)
+	}
+
+	var dexes []Dex
+	for page := 1; ; page++ {
+		dexesResp, err := c.getDexesPage(chain, page)
+		if err != nil {
+			return nil, err
+		}
+
+		dexes = append(dexes, dexesResp.Data.Dexes...)
+		if len(dexesResp.Data.Dexes) < _dexesPageSize || len(dexes) >= dexesResp.Data.Pagination.TotalItems {
+			return dexes, nil
+		}
+	}
+}
+
+// getDexesPage fetches a page of the enabled DEXes of chain
+func (c *KyberSwapClient) getDexesPage(chain string, page int) (*dexesResponse, error) {
+	params := url.Values{}
+	params.Set("chain", chain)
+	params.Set("isEnabled", "true")
+	params.Set("page", strconv.Itoa(page))
+	params.Set("pageSize", strconv.Itoa(_dexesPageSize))
+
+	url := fmt.Sprintf("%s/api/v1/dexes?%s", c.settingsURL, params.Encode())
+	req, err := c.newRequest(context.Background(), "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %w", err)
+	}
+
+	resp, err := c.do(EndpointDexes, req)
+	if err != nil {
+		return nil, fmt.Errorf("error sending request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading response body: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp.StatusCode, body)
+	}
+
+	var dexesResp dexesResponse
+	if err := c.decodeBody(resp.StatusCode, body, &dexesResp); err != nil {
+		return nil, fmt.Errorf("error decoding response: %w", err)
+	}
+	if dexesResp.Code != 0 {
+		return nil, &APIError{
+			StatusCode: resp.StatusCode,
+			Code:       int(dexesResp.Code),
+			Message:    dexesResp.Message,
+		}
+	}
+	return &dexesResp, nil
+}
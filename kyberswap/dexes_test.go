@@ -0,0 +1,56 @@
+package kyberswap
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestGetSupportedDexes(t *testing.T) {
+	server, client := NewTestServer(TestHandlers{})
+	defer server.Close()
+
+	dexes, err := client.GetSupportedDexes("ethereum")
+	if err != nil {
+		t.Fatalf("GetSupportedDexes() error = %v", err)
+	}
+	if len(dexes) != 3 || dexes[1].DexID != "uniswapv3" || dexes[1].Name != "Uniswap V3" {
+		t.Errorf("GetSupportedDexes() = %+v", dexes)
+	}
+
+	if _, err := client.GetSupportedDexes("solana"); err == nil {
+		t.Error("GetSupportedDexes() succeeded on an unsupported chain")
+	}
+}
+
+func TestGetSupportedDexes_Pages(t *testing.T) {
+	var queries []string
+	server, client := NewTestServer(TestHandlers{
+		Dexes: func(w http.ResponseWriter, r *http.Request) {
+			queries = append(queries, r.URL.RawQuery)
+
+			count := _dexesPageSize
+			if r.URL.Query().Get("page") == "2" {
+				count = 5
+			}
+			dexes := make([]string, count)
+			for i := range dexes {
+				dexes[i] = fmt.Sprintf(`{"dexId":"dex-%s-%d"}`, r.URL.Query().Get("page"), i)
+			}
+			fmt.Fprintf(w, `{"code":0,"data":{"dexes":[%s],"pagination":{"totalItems":%d}}}`, strings.Join(dexes, ","), _dexesPageSize+5)
+		},
+	})
+	defer server.Close()
+
+	dexes, err := client.GetSupportedDexes("arbitrum")
+	if err != nil {
+		t.Fatalf("GetSupportedDexes() error = %v", err)
+	}
+	if len(dexes) != _dexesPageSize+5 || dexes[_dexesPageSize].DexID != "dex-2-0" {
+		t.Errorf("got %d dexes", len(dexes))
+	}
+	if len(queries) != 2 || !strings.Contains(queries[0], "chain=arbitrum") || !strings.Contains(queries[0], "isEnabled=true") {
+		t.Errorf("queries = %v", queries)
+	}
+}
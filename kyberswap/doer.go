@@ -0,0 +1,30 @@
+package kyberswap
+
+import "net/http"
+
+// Doer sends HTTP requests, as *http.Client does. Providing one lets tests
+// serve recorded responses or wraps requests with middleware such as tracing.
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// WithDoer sends the requests through doer instead of the HTTP client. The
+// options configuring the HTTP client, such as WithTimeout and WithTimeouts,
+// don't apply to it, while a later WithHTTPClient replaces it.
+func WithDoer(doer Doer) Option {
+	return func(c *KyberSwapClient) {
+		c.doer = doer
+	}
+}
+
+// sender returns the Doer requests are sent through
+func (c *KyberSwapClient) sender() Doer {
+	var doer Doer = c.httpClient
+	if c.doer != nil {
+		doer = c.doer
+	}
+	if c.signer != nil {
+		return signingDoer{next: doer, sign: c.signer}
+	}
+	return doer
+}
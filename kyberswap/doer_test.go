@@ -0,0 +1,49 @@
+package kyberswap
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// doerFunc adapts a function to the Doer interface
+type doerFunc func(req *http.Request) (*http.Response, error)
+
+func (f doerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestWithDoer(t *testing.T) {
+	var traced []string
+	tracing := func(next Doer) Doer {
+		return doerFunc(func(req *http.Request) (*http.Response, error) {
+			traced = append(traced, req.Method+" "+req.URL.Path)
+			return next.Do(req)
+		})
+	}
+	recorded := doerFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{},
+			Body:       io.NopCloser(strings.NewReader(cannedRoutesResponse)),
+			Request:    req,
+		}, nil
+	})
+
+	client, err := NewClientWithOptions(WithBaseURL("http://kyberswap.invalid"), WithDoer(tracing(recorded)))
+	if err != nil {
+		t.Fatalf("NewClientWithOptions() error = %v", err)
+	}
+
+	route, err := client.GetRoutes(USDT, sUSDe, "1000000")
+	if err != nil {
+		t.Fatalf("GetRoutes() error = %v", err)
+	}
+	if route.Data.RouteSummary.AmountOut != "874518826958614826" {
+		t.Errorf("GetRoutes() amountOut = %s", route.Data.RouteSummary.AmountOut)
+	}
+	if len(traced) != 1 || traced[0] != "GET /ethereum/api/v1/routes" {
+		t.Errorf("traced = %v", traced)
+	}
+}
@@ -0,0 +1,33 @@
+package kyberswap
+
+import "context"
+
+// EncodedRoute holds the calldata of a built route, all a signer needs to
+// send the swap transaction
+type EncodedRoute struct {
+	Data             string
+	RouterAddress    string
+	TransactionValue string
+}
+
+// BuildRouteEncoded builds a route and returns only its calldata, router and
+// value. The server side simulation is skipped to lower latency, the caller
+// is expected to simulate or estimate gas itself.
+func (c *KyberSwapClient) BuildRouteEncoded(ctx context.Context, routeSummary RouteSummary, sender, recipient string, opts *BuildRouteOptions) (*EncodedRoute, error) {
+	lean := BuildRouteOptions{}
+	if opts != nil {
+		lean = *opts
+	}
+	lean.SkipSimulateTx = true
+
+	buildResp, err := c.BuildRouteCtx(ctx, routeSummary, sender, recipient, &lean)
+	if err != nil {
+		return nil, err
+	}
+
+	return &EncodedRoute{
+		Data:             buildResp.Data.Data,
+		RouterAddress:    buildResp.Data.RouterAddress,
+		TransactionValue: buildResp.Data.TransactionValue,
+	}, nil
+}
@@ -0,0 +1,41 @@
+package kyberswap
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestBuildRouteEncoded(t *testing.T) {
+	var body map[string]interface{}
+	server, client := NewTestServer(TestHandlers{
+		Build: func(w http.ResponseWriter, r *http.Request) {
+			json.NewDecoder(r.Body).Decode(&body)
+			w.Write([]byte(cannedBuildResponse))
+		},
+	})
+	defer server.Close()
+
+	sender := "0xd46B96d15ffF9b2B17e9c788086f3159bD0e8355"
+	opts := &BuildRouteOptions{SlippageTolerance: 50}
+	got, err := client.BuildRouteEncoded(context.Background(), RouteSummary{}, sender, sender, opts)
+	if err != nil {
+		t.Fatalf("BuildRouteEncoded() error = %v", err)
+	}
+
+	want := EncodedRoute{
+		Data:             "0xe21fd0e9",
+		RouterAddress:    "0x6131B5fae19EA4f9D964eAc0408E4408b66337b5",
+		TransactionValue: "0",
+	}
+	if *got != want {
+		t.Errorf("BuildRouteEncoded() = %+v, want %+v", *got, want)
+	}
+	if body["skipSimulateTx"] != true || body["slippageTolerance"] != float64(50) {
+		t.Errorf("request body = %v", body)
+	}
+	if opts.SkipSimulateTx {
+		t.Errorf("BuildRouteEncoded() modified the caller's options")
+	}
+}
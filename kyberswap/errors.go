@@ -0,0 +1,85 @@
+package kyberswap
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/ThreeAndTwo/dex-swap-api-helper/internal/address"
+	"github.com/ThreeAndTwo/dex-swap-api-helper/units"
+)
+
+var (
+	// ErrNoRoute is returned when KyberSwap has no route for the requested pair
+	ErrNoRoute = errors.New("kyberswap: no route found")
+	// ErrRateLimited is returned when KyberSwap rejects the request with 429
+	ErrRateLimited = errors.New("kyberswap: rate limited")
+	// ErrInvalidAddress is returned for malformed token or wallet addresses
+	ErrInvalidAddress = address.ErrInvalid
+	// ErrInvalidAmount is returned for amounts that aren't raw integer amounts
+	ErrInvalidAmount = units.ErrInvalidAmount
+	// ErrOutputDegraded is returned when the built output dropped beyond the
+	// accepted threshold since routing
+	ErrOutputDegraded = errors.New("kyberswap: output degraded")
+	// ErrUnexpectedValue is returned when the native value of a built
+	// transaction doesn't match its input token
+	ErrUnexpectedValue = errors.New("kyberswap: unexpected transaction value")
+	// ErrStaleRoute is returned when building a route summary older than the
+	// max age set by WithMaxRouteAge
+	ErrStaleRoute = errors.New("kyberswap: stale route")
+	// ErrAmountTooSmall is returned without calling KyberSwap for amounts
+	// below the minimum set by WithMinAmount or WithMinAmountUSD
+	ErrAmountTooSmall = errors.New("kyberswap: amount too small")
+)
+
+// KyberSwap error codes that mean no route exists for the request
+const (
+	codeRouteNotFound   = 4008
+	codeNoEligiblePools = 4010
+)
+
+// APIError represents an error response returned by the KyberSwap API
+type APIError struct {
+	StatusCode int
+	Code       int
+	Message    string
+	RequestID  string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("unexpected status code: %d, code: %d, message: %s, request id: %s",
+		e.StatusCode, e.Code, e.Message, e.RequestID)
+}
+
+// Is makes APIError match ErrNoRoute and ErrRateLimited with errors.Is
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrNoRoute:
+		return e.Code == codeRouteNotFound || e.Code == codeNoEligiblePools
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	}
+	return false
+}
+
+// newAPIError builds an APIError from a failed response, falling back to the
+// raw body as message when it isn't the usual JSON error envelope
+func newAPIError(statusCode int, body []byte) *APIError {
+	apiErr := &APIError{StatusCode: statusCode}
+
+	var errResp struct {
+		Code      int    `json:"code"`
+		Message   string `json:"message"`
+		RequestId string `json:"requestId"`
+	}
+	if err := json.Unmarshal(body, &errResp); err != nil {
+		apiErr.Message = string(body)
+		return apiErr
+	}
+
+	apiErr.Code = errResp.Code
+	apiErr.Message = errResp.Message
+	apiErr.RequestID = errResp.RequestId
+	return apiErr
+}
@@ -0,0 +1,88 @@
+package kyberswap
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetRoutes_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		body       string
+		target     error
+	}{
+		{
+			name:       "route not found",
+			statusCode: http.StatusBadRequest,
+			body:       `{"code":4008,"message":"route not found","requestId":"abc"}`,
+			target:     ErrNoRoute,
+		},
+		{
+			name:       "rate limited",
+			statusCode: http.StatusTooManyRequests,
+			body:       `too many requests`,
+			target:     ErrRateLimited,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.statusCode)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			client := NewClient(server.URL, chain)
+			_, err := client.GetRoutes(USDT, sUSDe, "1000000")
+			if !errors.Is(err, tt.target) {
+				t.Fatalf("GetRoutes() error = %v, want %v", err, tt.target)
+			}
+
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("GetRoutes() error = %v, want *APIError", err)
+			}
+			if apiErr.StatusCode != tt.statusCode {
+				t.Errorf("APIError.StatusCode = %d, want %d", apiErr.StatusCode, tt.statusCode)
+			}
+		})
+	}
+}
+
+func TestKyberSwapClient_GetRoutesWithMeta(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "3")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, chain)
+	_, header, err := client.GetRoutesWithMeta(context.Background(), USDT, sUSDe, "1000000", nil)
+	if !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("GetRoutesWithMeta() error = %v, want ErrRateLimited", err)
+	}
+	if header.Get("Retry-After") != "3" {
+		t.Errorf("Retry-After = %q, want 3", header.Get("Retry-After"))
+	}
+}
+
+func TestBuildRoute_ErrorCode(t *testing.T) {
+	server, client := NewTestServer(TestHandlers{
+		Build: func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"code":4227,"message":"estimate gas failed","requestId":"abc"}`))
+		},
+	})
+	defer server.Close()
+
+	sender := "0xd46B96d15ffF9b2B17e9c788086f3159bD0e8355"
+	_, err := client.BuildRoute(RouteSummary{}, sender, sender)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusOK || apiErr.Code != 4227 || apiErr.RequestID != "abc" {
+		t.Fatalf("BuildRoute() error = %v, want *APIError with code 4227", err)
+	}
+}
@@ -0,0 +1,65 @@
+package kyberswap
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrUnknownPoolType is returned by DecodeExtra for pool types without a typed extra
+var ErrUnknownPoolType = errors.New("kyberswap: unknown pool type")
+
+// Pool types with a typed extra
+const (
+	PoolTypeUniswapV2 = "uniswap-v2"
+	PoolTypeUniswapV3 = "uniswap-v3"
+	poolTypeCurve     = "curve-"
+)
+
+// UniswapV2Extra represents the extra of a Uniswap V2 style pool swap
+type UniswapV2Extra struct {
+	Fee          uint64 `json:"fee"`
+	FeePrecision uint64 `json:"feePrecision"`
+}
+
+// UniswapV3Extra represents the pool state after a Uniswap V3 style swap
+type UniswapV3Extra struct {
+	NextStateSqrtPriceX96 json.Number `json:"nextStateSqrtPriceX96"`
+	NextStateLiquidity    json.Number `json:"nextStateLiquidity"`
+	NextStateTickCurrent  int         `json:"nextStateTickCurrent"`
+}
+
+// CurveExtra represents the coin indexes used by a Curve pool swap
+type CurveExtra struct {
+	TokenInIndex  int  `json:"tokenInIndex"`
+	TokenOutIndex int  `json:"tokenOutIndex"`
+	Underlying    bool `json:"underlying"`
+}
+
+// DecodeExtra unmarshals Extra into the typed extra of the route PoolType:
+// *UniswapV2Extra, *UniswapV3Extra or *CurveExtra for every curve-* pool type
+func (r Route) DecodeExtra() (interface{}, error) {
+	var extra interface{}
+	switch {
+	case r.PoolType == PoolTypeUniswapV2:
+		extra = &UniswapV2Extra{}
+	case r.PoolType == PoolTypeUniswapV3:
+		extra = &UniswapV3Extra{}
+	case strings.HasPrefix(r.PoolType, poolTypeCurve):
+		extra = &CurveExtra{}
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownPoolType, r.PoolType)
+	}
+
+	raw, err := json.Marshal(r.Extra)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling extra: %w", err)
+	}
+
+	if err := json.Unmarshal(raw, extra); err != nil {
+		return nil, fmt.Errorf("error decoding %s extra: %w", r.PoolType, err)
+	}
+
+	return extra, nil
+}
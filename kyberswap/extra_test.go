@@ -0,0 +1,59 @@
+package kyberswap
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestRoute_DecodeExtra(t *testing.T) {
+	tests := []struct {
+		name    string
+		route   string
+		want    interface{}
+		wantErr error
+	}{
+		{
+			name:  "uniswap v2",
+			route: `{"poolType":"uniswap-v2","extra":{"fee":3,"feePrecision":1000}}`,
+			want:  &UniswapV2Extra{Fee: 3, FeePrecision: 1000},
+		},
+		{
+			name:  "uniswap v3",
+			route: `{"poolType":"uniswap-v3","extra":{"nextStateSqrtPriceX96":"79228162514264337593543950336","nextStateLiquidity":1000,"nextStateTickCurrent":-12}}`,
+			want: &UniswapV3Extra{
+				NextStateSqrtPriceX96: "79228162514264337593543950336",
+				NextStateLiquidity:    "1000",
+				NextStateTickCurrent:  -12,
+			},
+		},
+		{
+			name:  "curve",
+			route: `{"poolType":"curve-stable-plain","extra":{"tokenInIndex":1,"tokenOutIndex":2,"underlying":true}}`,
+			want:  &CurveExtra{TokenInIndex: 1, TokenOutIndex: 2, Underlying: true},
+		},
+		{
+			name:    "unknown",
+			route:   `{"poolType":"kyber-pmm","extra":{}}`,
+			wantErr: ErrUnknownPoolType,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var route Route
+			if err := json.Unmarshal([]byte(tt.route), &route); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := route.DecodeExtra()
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("DecodeExtra() error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) && tt.wantErr == nil {
+				t.Errorf("DecodeExtra() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package kyberswap
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// CurvePoint is the route found for one input amount of a price impact curve
+type CurvePoint struct {
+	// AmountIn and AmountOut are raw integer amounts
+	AmountIn  string
+	AmountOut string
+	// EffectivePrice is AmountOut / AmountIn in raw units
+	EffectivePrice float64
+	// MarginalPrice is the price the input added since the previous point
+	// got, (AmountOut - prev.AmountOut) / (AmountIn - prev.AmountIn). It
+	// equals EffectivePrice for the first point.
+	MarginalPrice float64
+	// MarginalImpact is the relative drop of MarginalPrice from the
+	// previous EffectivePrice, 0.01 meaning the added input was priced 1%
+	// worse. It is 0 for the first point.
+	MarginalImpact float64
+}
+
+// GetPriceImpactCurve fetches routes for the pair at each of amounts, raw
+// integer amounts sorted in increasing order, with at most concurrency
+// requests in flight, and returns the effective and marginal price of each
+// size, e.g. to size a large order. Every amount must be routed: a curve
+// with holes would misstate the marginal prices, so the first failure is
+// returned instead.
+func (c *KyberSwapClient) GetPriceImpactCurve(tokenIn, tokenOut string, amounts []string, concurrency int) ([]CurvePoint, error) {
+	in := make([]*big.Int, len(amounts))
+	for i, amount := range amounts {
+		if err := validateAmount(amount); err != nil {
+			return nil, err
+		}
+		in[i], _ = new(big.Int).SetString(amount, 10)
+		if in[i].Sign() <= 0 {
+			return nil, fmt.Errorf("%w: %q must be positive", ErrInvalidAmount, amount)
+		}
+		if i > 0 && in[i].Cmp(in[i-1]) <= 0 {
+			return nil, fmt.Errorf("%w: amounts must be strictly increasing, %s follows %s", ErrInvalidAmount, amount, amounts[i-1])
+		}
+	}
+
+	resps, errs := c.GetRoutesMulti(tokenIn, tokenOut, amounts, concurrency)
+	for i, err := range errs {
+		if err != nil {
+			return nil, fmt.Errorf("error getting routes for amount %s: %w", amounts[i], err)
+		}
+	}
+
+	points := make([]CurvePoint, len(amounts))
+	var prevOut *big.Int
+	for i, resp := range resps {
+		amountOut := resp.Data.RouteSummary.AmountOut
+		out, ok := new(big.Int).SetString(amountOut, 10)
+		if !ok {
+			return nil, fmt.Errorf("error parsing amountOut %q for amount %s: %w", amountOut, amounts[i], ErrInvalidAmount)
+		}
+
+		point := CurvePoint{
+			AmountIn:       amounts[i],
+			AmountOut:      amountOut,
+			EffectivePrice: ratio(out, in[i]),
+		}
+		point.MarginalPrice = point.EffectivePrice
+		if i > 0 {
+			prev := points[i-1]
+			point.MarginalPrice = ratio(new(big.Int).Sub(out, prevOut), new(big.Int).Sub(in[i], in[i-1]))
+			if prev.EffectivePrice > 0 {
+				point.MarginalImpact = 1 - point.MarginalPrice/prev.EffectivePrice
+			}
+		}
+		points[i] = point
+		prevOut = out
+	}
+
+	return points, nil
+}
+
+// ratio returns num / den as a float64, den must not be zero
+func ratio(num, den *big.Int) float64 {
+	f, _ := new(big.Float).Quo(new(big.Float).SetInt(num), new(big.Float).SetInt(den)).Float64()
+	return f
+}
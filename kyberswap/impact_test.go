@@ -0,0 +1,69 @@
+package kyberswap
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"testing"
+)
+
+func TestGetPriceImpactCurve(t *testing.T) {
+	// the pool pays 2 per unit for the first 1000 units and 1 per unit after
+	server, client := NewTestServer(TestHandlers{
+		Routes: func(w http.ResponseWriter, r *http.Request) {
+			amountIn, _ := strconv.Atoi(r.URL.Query().Get("amountIn"))
+			amountOut := 2 * min(amountIn, 1000)
+			amountOut += max(amountIn-1000, 0)
+			fmt.Fprintf(w, `{"code":0,"data":{"routeSummary":{"amountIn":"%d","amountOut":"%d"}}}`, amountIn, amountOut)
+		},
+	})
+	defer server.Close()
+
+	points, err := client.GetPriceImpactCurve(USDT, sUSDe, []string{"500", "1000", "2000"}, 2)
+	if err != nil {
+		t.Fatalf("GetPriceImpactCurve() error = %v", err)
+	}
+
+	want := []CurvePoint{
+		{AmountIn: "500", AmountOut: "1000", EffectivePrice: 2, MarginalPrice: 2},
+		{AmountIn: "1000", AmountOut: "2000", EffectivePrice: 2, MarginalPrice: 2},
+		{AmountIn: "2000", AmountOut: "3000", EffectivePrice: 1.5, MarginalPrice: 1, MarginalImpact: 0.5},
+	}
+	if len(points) != len(want) {
+		t.Fatalf("GetPriceImpactCurve() = %+v", points)
+	}
+	for i := range want {
+		got := points[i]
+		if got.AmountIn != want[i].AmountIn || got.AmountOut != want[i].AmountOut ||
+			math.Abs(got.EffectivePrice-want[i].EffectivePrice) > 1e-9 ||
+			math.Abs(got.MarginalPrice-want[i].MarginalPrice) > 1e-9 ||
+			math.Abs(got.MarginalImpact-want[i].MarginalImpact) > 1e-9 {
+			t.Errorf("point %d = %+v, want %+v", i, got, want[i])
+		}
+	}
+}
+
+func TestGetPriceImpactCurve_Invalid(t *testing.T) {
+	server, client := NewTestServer(TestHandlers{
+		Routes: func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("amountIn") == "3000" {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"code":4008,"message":"route not found"}`))
+				return
+			}
+			w.Write([]byte(`{"code":0,"data":{"routeSummary":{"amountOut":"1"}}}`))
+		},
+	})
+	defer server.Close()
+
+	for _, amounts := range [][]string{{"2000", "1000"}, {"1000", "1000"}, {"0", "1"}, {"1.5"}} {
+		if _, err := client.GetPriceImpactCurve(USDT, sUSDe, amounts, 1); !errors.Is(err, ErrInvalidAmount) {
+			t.Errorf("GetPriceImpactCurve(%v) error = %v, want ErrInvalidAmount", amounts, err)
+		}
+	}
+	if _, err := client.GetPriceImpactCurve(USDT, sUSDe, []string{"1000", "3000"}, 1); err == nil {
+		t.Error("GetPriceImpactCurve() with an unroutable amount error = nil")
+	}
+}
@@ -0,0 +1,33 @@
+//go:build dexinsecure
+
+package kyberswap
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestWithInsecureTLS(t *testing.T) {
+	c := NewClient("", "")
+	WithInsecureTLS()(c)
+
+	transport, ok := c.httpClient.Transport.(*http.Transport)
+	if !ok || !transport.TLSClientConfig.InsecureSkipVerify {
+		t.Fatalf("transport = %+v, want TLS verification disabled", c.httpClient.Transport)
+	}
+	if http.DefaultTransport.(*http.Transport).TLSClientConfig != nil &&
+		http.DefaultTransport.(*http.Transport).TLSClientConfig.InsecureSkipVerify {
+		t.Errorf("WithInsecureTLS() modified http.DefaultTransport")
+	}
+}
+
+func TestWithInsecureTLS_SharedHTTPClient(t *testing.T) {
+	shared := &http.Client{}
+	c := NewClient("", chain)
+	WithHTTPClient(shared)(c)
+	WithInsecureTLS()(c)
+
+	if shared.Transport != nil || c.httpClient == shared {
+		t.Errorf("WithInsecureTLS() modified the shared HTTP client: %+v", shared)
+	}
+}
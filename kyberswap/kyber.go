@@ -2,12 +2,26 @@ package kyberswap
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync/atomic"
 	"time"
 
+	"github.com/ThreeAndTwo/dex-swap-api-helper/internal/address"
+	"github.com/ThreeAndTwo/dex-swap-api-helper/internal/breaker"
+	"github.com/ThreeAndTwo/dex-swap-api-helper/internal/codec"
+	"github.com/ThreeAndTwo/dex-swap-api-helper/internal/drain"
+	"github.com/ThreeAndTwo/dex-swap-api-helper/internal/idempotency"
+	"github.com/ThreeAndTwo/dex-swap-api-helper/internal/retry"
+	"github.com/ThreeAndTwo/dex-swap-api-helper/internal/stats"
+	"github.com/ThreeAndTwo/dex-swap-api-helper/internal/version"
+	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 )
 
@@ -17,8 +31,26 @@ const (
 
 // Client represents a KyberSwap API client
 type KyberSwapClient struct {
-	httpClient *http.Client
-	baseURL    string
+	httpClient    *http.Client
+	sharedHTTP    bool // httpClient is used by other clients too, see Shutdown
+	doer          Doer
+	signer        RequestSigner
+	baseURL       string
+	chain         *atomic.Pointer[string]
+	settingsURL   string
+	logger        zerolog.Logger
+	clientID      string
+	customHeaders http.Header
+	minAmounts    *minAmounts
+	recorder      Recorder
+	capture       RawCapture
+	breaker       *breaker.Breaker
+	retry         *retry.Policy
+	maxAge        time.Duration
+	userAgent     string
+	stats         *stats.Tracker
+	drain         *drain.Group
+	codec         Codec
 }
 
 // RouteResponse represents the API response structure
@@ -30,6 +62,8 @@ type RouteResponse struct {
 		RouterAddress string       `json:"routerAddress"`
 	} `json:"data"`
 	RequestId string `json:"requestId"`
+	// FetchedAt is when the routes were received
+	FetchedAt time.Time `json:"-"`
 }
 
 // RouteSummary represents the route summary information
@@ -47,6 +81,12 @@ type RouteSummary struct {
 	GasUsd                       string    `json:"gasUsd"`
 	ExtraFee                     ExtraFee  `json:"extraFee"`
 	Route                        [][]Route `json:"route"`
+
+	// fetchedAt is when the summary was received, checked by BuildRoute
+	// against the max age set by WithMaxRouteAge
+	fetchedAt time.Time
+	// raw holds the summary exactly as sent by KyberSwap, see MarshalJSON
+	raw json.RawMessage
 }
 
 // ExtraFee represents the fee information
@@ -81,12 +121,126 @@ type OuterPoolExtra struct {
 	TokenOutIsNative bool  `json:"TokenOutIsNative"`
 }
 
+// GetRoutesOptions holds the optional parameters of GetRoutes
+type GetRoutesOptions struct {
+	// FeeAmount is the partner fee, in bps of the amount when IsInBps is set
+	// or in wei of the charged token otherwise
+	FeeAmount string
+	// ChargeFeeBy selects the token the fee is taken from: "currency_in" or "currency_out"
+	ChargeFeeBy string
+	IsInBps     bool
+	FeeReceiver string
+	// BaseURL overrides the client base URL for this request only, e.g. to
+	// hit a regional mirror or a staging endpoint. The chain is still appended
+	BaseURL string
+	// SaveGas prefers routes with fewer hops over the best raw output
+	SaveGas bool
+	// IncludedSources restricts routing to these liquidity sources, the
+	// DexID of the DEXes listed by GetSupportedDexes
+	IncludedSources []string
+	// ExcludedSources removes these liquidity sources (dex ids) from routing
+	ExcludedSources []string
+}
+
+// baseURL returns the per-call base URL override, empty when unset
+func (o *GetRoutesOptions) baseURL() string {
+	if o == nil {
+		return ""
+	}
+	return o.BaseURL
+}
+
+// apply sets the query parameters for the configured options
+func (o *GetRoutesOptions) apply(params url.Values) {
+	if o == nil {
+		return
+	}
+
+	if o.FeeAmount != "" {
+		params.Set("feeAmount", o.FeeAmount)
+		params.Set("chargeFeeBy", o.ChargeFeeBy)
+		params.Set("isInBps", strconv.FormatBool(o.IsInBps))
+		params.Set("feeReceiver", o.FeeReceiver)
+	}
+	if o.SaveGas {
+		params.Set("saveGas", "true")
+	}
+	if len(o.IncludedSources) > 0 {
+		params.Set("includedSources", strings.Join(o.IncludedSources, ","))
+	}
+	if len(o.ExcludedSources) > 0 {
+		params.Set("excludedSources", strings.Join(o.ExcludedSources, ","))
+	}
+}
+
 type BuildRouteRequest struct {
 	RouteSummary      RouteSummary `json:"routeSummary"`
 	Sender            string       `json:"sender"`
 	Recipient         string       `json:"recipient"`
 	Deadline          int64        `json:"deadline"`
 	SlippageTolerance int64        `json:"slippageTolerance"`
+	Permit            string       `json:"permit,omitempty"`
+	SkipSimulateTx    bool         `json:"skipSimulateTx,omitempty"`
+	Source            string       `json:"source,omitempty"`
+}
+
+// BuildRouteOptions holds the optional parameters of BuildRoute
+type BuildRouteOptions struct {
+	// SlippageTolerance in bps, 10 means 0.1%. Defaults to 10 when 0
+	SlippageTolerance int64
+	// Deadline of the swap transaction. Defaults to 20 hours from now when zero
+	Deadline time.Time
+	// Permit is the hex encoded signed EIP-2612 permit or Permit2 data of
+	// tokenIn, letting the router pull the tokens without an approval tx
+	Permit string
+	// SkipSimulateTx skips the server side simulation of the built transaction,
+	// which can reject valid swaps from wallets funded just in time
+	SkipSimulateTx bool
+	// Source identifies the integrator for KyberSwap partner attribution
+	Source string
+	// BaseURL overrides the client base URL for this request only, e.g. to
+	// hit a regional mirror or a staging endpoint. The chain is still appended
+	BaseURL string
+	// MaxOutputDropPercent rejects builds whose output dropped by more than
+	// this percent since routing with ErrOutputDegraded, 0 disables the check
+	MaxOutputDropPercent float64
+	// IdempotencyKey is sent in the Idempotency-Key header so the provider
+	// or a gateway can dedupe a repeated build, e.g. when the caller retries
+	// after a timeout. A random key is generated when empty.
+	IdempotencyKey string
+}
+
+// idempotencyKey returns the configured key, a new random one when unset
+func (o *BuildRouteOptions) idempotencyKey() string {
+	if o == nil || o.IdempotencyKey == "" {
+		return idempotency.NewKey()
+	}
+	return o.IdempotencyKey
+}
+
+// baseURL returns the per-call base URL override, empty when unset
+func (o *BuildRouteOptions) baseURL() string {
+	if o == nil {
+		return ""
+	}
+	return o.BaseURL
+}
+
+// apply overrides the request defaults with the configured options
+func (o *BuildRouteOptions) apply(req *BuildRouteRequest) {
+	if o == nil {
+		return
+	}
+
+	if o.SlippageTolerance != 0 {
+		req.SlippageTolerance = o.SlippageTolerance
+	}
+	if !o.Deadline.IsZero() {
+		req.Deadline = o.Deadline.Unix()
+	}
+	req.Permit = o.Permit
+	req.SkipSimulateTx = o.SkipSimulateTx
+	req.Source = o.Source
 }
 
 // BuildRouteResponse represents the response from building a route
@@ -129,40 +283,167 @@ func NewClient(baseURL, chain string) *KyberSwapClient {
 		httpClient: &http.Client{
 			Timeout: 10 * time.Second,
 		},
-		baseURL: fmt.Sprintf("%s/%s", baseURL, chain),
+		baseURL:     baseURL,
+		settingsURL: _settingsURL,
+		chain:       newChain(chain),
+		logger:      log.Logger,
+		userAgent:   version.UserAgent,
+		stats:       stats.New(),
+		drain:       drain.New(),
+		codec:       codec.Standard,
+	}
+}
+
+// newRequest creates a request carrying the headers common to every call
+func (c *KyberSwapClient) newRequest(ctx context.Context, method, url string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("User-Agent", c.userAgent)
+	if c.clientID != "" {
+		req.Header.Set(_clientIDHeader, c.clientID)
+	}
+	for key, values := range c.customHeaders {
+		req.Header[key] = values
+	}
+	return req, nil
+}
+
+// NewClientChecked creates a new KyberSwap client, returning an error if
+// chain is not a supported chain name
+func NewClientChecked(baseURL, chain string) (*KyberSwapClient, error) {
+	if chain != "" && !IsSupportedChain(chain) {
+		return nil, fmt.Errorf("unsupported chain: %q", chain)
 	}
+
+	return NewClient(baseURL, chain), nil
 }
 
 // GetRoutes fetches routes for token swap
 func (c *KyberSwapClient) GetRoutes(tokenIn, tokenOut, amountIn string) (*RouteResponse, error) {
-	url := fmt.Sprintf("%s/api/v1/routes?tokenIn=%s&tokenOut=%s&amountIn=%s",
-		c.baseURL, tokenIn, tokenOut, amountIn)
-	log.Info().Msgf("url: %s", url)
-	req, err := http.NewRequest("GET", url, nil)
+	return c.GetRoutesWithOptions(tokenIn, tokenOut, amountIn, nil)
+}
+
+// GetRoutesWithOptions fetches routes for token swap with optional parameters
+func (c *KyberSwapClient) GetRoutesWithOptions(tokenIn, tokenOut, amountIn string, opts *GetRoutesOptions) (*RouteResponse, error) {
+	return c.GetRoutesCtx(context.Background(), tokenIn, tokenOut, amountIn, opts)
+}
+
+// GetRoutesCtx fetches routes for token swap, bounded by ctx
+func (c *KyberSwapClient) GetRoutesCtx(ctx context.Context, tokenIn, tokenOut, amountIn string, opts *GetRoutesOptions) (*RouteResponse, error) {
+	routeResp, _, err := c.GetRoutesWithMeta(ctx, tokenIn, tokenOut, amountIn, opts)
+	return routeResp, err
+}
+
+// GetRoutesWithMeta fetches routes for token swap and returns the response
+// headers along with them, also when the request fails with a status error
+func (c *KyberSwapClient) GetRoutesWithMeta(ctx context.Context, tokenIn, tokenOut, amountIn string, opts *GetRoutesOptions) (*RouteResponse, http.Header, error) {
+	tokenIn, err := address.Normalize(toKyberToken(tokenIn))
+	if err != nil {
+		return nil, nil, fmt.Errorf("tokenIn: %w", err)
+	}
+	tokenOut, err = address.Normalize(toKyberToken(tokenOut))
 	if err != nil {
-		return nil, fmt.Errorf("error creating request: %w", err)
+		return nil, nil, fmt.Errorf("tokenOut: %w", err)
+	}
+	if err := validateAmount(amountIn); err != nil {
+		return nil, nil, fmt.Errorf("amountIn: %w", err)
+	}
+	if err := c.minAmounts.check(c.Chain(), tokenIn, amountIn); err != nil {
+		return nil, nil, err
 	}
 
-	resp, err := c.httpClient.Do(req)
+	params := url.Values{}
+	params.Set("tokenIn", tokenIn)
+	params.Set("tokenOut", tokenOut)
+	params.Set("amountIn", amountIn)
+	opts.apply(params)
+
+	url := fmt.Sprintf("%s/api/v1/routes?%s", c.chainURLFor(opts.baseURL()), params.Encode())
+	c.logger.Debug().
+		Str("endpoint", EndpointRoutes).
+		Str("chain", c.Chain()).
+		Str("token_in", tokenIn).
+		Str("token_out", tokenOut).
+		Str("amount_in", amountIn).
+		Msg("sending request")
+	req, err := c.newRequest(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error creating request: %w", err)
+	}
+
+	resp, err := c.do(EndpointRoutes, req)
 	if err != nil {
-		return nil, fmt.Errorf("error sending request: %w", err)
+		return nil, nil, fmt.Errorf("error sending request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, resp.Header, fmt.Errorf("status code %d, failed to read error response: %w", resp.StatusCode, err)
+		}
+		return nil, resp.Header, newAPIError(resp.StatusCode, body)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, resp.Header, fmt.Errorf("error reading response body: %w", err)
 	}
 
 	var routeResp RouteResponse
-	if err := json.NewDecoder(resp.Body).Decode(&routeResp); err != nil {
-		return nil, fmt.Errorf("error decoding response: %w", err)
+	if err := c.decodeBody(resp.StatusCode, body, &routeResp); err != nil {
+		return nil, resp.Header, fmt.Errorf("error decoding response: %w", err)
+	}
+
+	routeResp.FetchedAt = time.Now()
+	c.minAmounts.observe(c.Chain(), routeResp.Data.RouteSummary)
+	routeResp.Data.RouteSummary.fetchedAt = routeResp.FetchedAt
+
+	if routeResp.Code != 0 {
+		return nil, resp.Header, &APIError{
+			StatusCode: resp.StatusCode,
+			Code:       int(routeResp.Code),
+			Message:    routeResp.Message,
+			RequestID:  routeResp.RequestId,
+		}
 	}
 
-	return &routeResp, nil
+	return &routeResp, resp.Header, nil
 }
 
-// BuildRoute sends a request to build a route
+// BuildRoute sends a request to build a route. An empty recipient receives
+// the output at sender, the zero and native token addresses are rejected for
+// both with ErrInvalidAddress.
 func (c *KyberSwapClient) BuildRoute(routeSummary RouteSummary, sender, recipient string) (*BuildRouteResponse, error) {
+	return c.BuildRouteCtx(context.Background(), routeSummary, sender, recipient, nil)
+}
+
+// BuildRouteCtx sends a request to build a route with optional parameters,
+// bounded by ctx
+func (c *KyberSwapClient) BuildRouteCtx(ctx context.Context, routeSummary RouteSummary, sender, recipient string, opts *BuildRouteOptions) (*BuildRouteResponse, error) {
+	buildResp, _, err := c.BuildRouteWithMeta(ctx, routeSummary, sender, recipient, opts)
+	return buildResp, err
+}
+
+// BuildRouteWithMeta sends a request to build a route and returns the
+// response headers along with it, also when the request fails with a status error
+func (c *KyberSwapClient) BuildRouteWithMeta(ctx context.Context, routeSummary RouteSummary, sender, recipient string, opts *BuildRouteOptions) (*BuildRouteResponse, http.Header, error) {
+	if recipient == "" {
+		recipient = sender
+	}
+	if err := validateParty(sender); err != nil {
+		return nil, nil, fmt.Errorf("sender: %w", err)
+	}
+	if err := validateParty(recipient); err != nil {
+		return nil, nil, fmt.Errorf("recipient: %w", err)
+	}
+	if err := c.checkRouteAge(routeSummary); err != nil {
+		return nil, nil, err
+	}
+
 	reqBody := BuildRouteRequest{
 		RouteSummary:      routeSummary,
 		Sender:            sender,
@@ -170,46 +451,121 @@ func (c *KyberSwapClient) BuildRoute(routeSummary RouteSummary, sender, recipien
 		Deadline:          time.Now().Unix() + 20*3600, // TODO: need deleted
 		SlippageTolerance: 10,                          // 0.1%
 	}
+	opts.apply(&reqBody)
+	// encode the summary up front so a summary built by the caller goes
+	// through the client codec too
+	summary, err := reqBody.RouteSummary.marshalWith(c.codec)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error marshaling route summary: %w", err)
+	}
+	reqBody.RouteSummary.raw = summary
 
-	jsonBody, err := json.Marshal(reqBody)
+	jsonBody, err := c.codec.Marshal(reqBody)
 	if err != nil {
-		return nil, fmt.Errorf("error marshaling request: %w", err)
+		return nil, nil, fmt.Errorf("error marshaling request: %w", err)
 	}
 
-	log.Debug().Msgf("jsonBody: %s", string(jsonBody))
+	c.logger.Debug().
+		Str("endpoint", EndpointBuild).
+		Str("chain", c.Chain()).
+		Str("sender", address.Redact(sender)).
+		Str("recipient", address.Redact(recipient)).
+		Int64("slippage_tolerance", reqBody.SlippageTolerance).
+		Msg("sending request")
 
-	url := fmt.Sprintf("%s/api/v1/route/build", c.baseURL)
-	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonBody))
+	url := fmt.Sprintf("%s/api/v1/route/build", c.chainURLFor(opts.baseURL()))
+	req, err := c.newRequest(ctx, "POST", url, bytes.NewBuffer(jsonBody))
 	if err != nil {
-		return nil, fmt.Errorf("error creating request: %w", err)
+		return nil, nil, fmt.Errorf("error creating request: %w", err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(idempotency.Header, opts.idempotencyKey())
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(EndpointBuild, req)
 	if err != nil {
-		return nil, fmt.Errorf("error sending request: %w", err)
+		return nil, nil, fmt.Errorf("error sending request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		body, err := io.ReadAll(resp.Body)
 		if err != nil {
-			return nil, fmt.Errorf("status code %d, failed to read error response: %w", resp.StatusCode, err)
+			return nil, resp.Header, fmt.Errorf("status code %d, failed to read error response: %w", resp.StatusCode, err)
 		}
-		return nil, fmt.Errorf("unexpected status code: %d: %s", resp.StatusCode, string(body))
+		return nil, resp.Header, newAPIError(resp.StatusCode, body)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, resp.Header, fmt.Errorf("error reading response body: %w", err)
 	}
 
 	var buildResp BuildRouteResponse
-	if err := json.NewDecoder(resp.Body).Decode(&buildResp); err != nil {
-		return nil, fmt.Errorf("error decoding response: %w", err)
+	if err := c.decodeBody(resp.StatusCode, body, &buildResp); err != nil {
+		return nil, resp.Header, fmt.Errorf("error decoding response: %w", err)
+	}
+
+	if buildResp.Code != 0 {
+		return nil, resp.Header, &APIError{
+			StatusCode: resp.StatusCode,
+			Code:       int(buildResp.Code),
+			Message:    buildResp.Message,
+			RequestID:  buildResp.RequestId,
+		}
+	}
+
+	if opts != nil && opts.MaxOutputDropPercent > 0 {
+		if err := buildResp.CheckOutput(opts.MaxOutputDropPercent); err != nil {
+			return nil, resp.Header, err
+		}
+	}
+
+	return &buildResp, resp.Header, nil
+}
+
+// SetChain switches the client to another chain, keeping the configured
+// HTTP client and settings. It is safe to call while requests are in
+// flight, though to route on several chains at once prefer a client per
+// chain, see NewMultiChainClient.
+func (c *KyberSwapClient) SetChain(chain string) error {
+	if !IsSupportedChain(chain) {
+		return fmt.Errorf("unsupported chain: %q", chain)
 	}
 
-	return &buildResp, nil
+	c.chain.Store(&chain)
+	return nil
+}
+
+// Chain returns the chain name the client is routing on
+func (c *KyberSwapClient) Chain() string {
+	return *c.chain.Load()
+}
+
+// newChain returns the chain of a new client, a pointer so that copies of
+// the client made by NewMultiChainClient can each get their own
+func newChain(chain string) *atomic.Pointer[string] {
+	var p atomic.Pointer[string]
+	p.Store(&chain)
+	return &p
+}
+
+// chainURL returns the chain scoped base URL of the aggregator API
+func (c *KyberSwapClient) chainURL() string {
+	return c.chainURLFor("")
+}
+
+// chainURLFor returns the chain scoped URL below baseURL, falling back to the
+// client base URL when baseURL is empty
+func (c *KyberSwapClient) chainURLFor(baseURL string) string {
+	if baseURL == "" {
+		baseURL = c.baseURL
+	}
+	return fmt.Sprintf("%s/%s", baseURL, c.Chain())
 }
 
 // WithTimeout sets a custom timeout for the HTTP client
 func (c *KyberSwapClient) WithTimeout(timeout time.Duration) *KyberSwapClient {
-	c.httpClient.Timeout = timeout
+	c.ownHTTPClient().Timeout = timeout
 	return c
 }
@@ -0,0 +1,115 @@
+package kyberswap
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestKyberSwapClient_GetRoutesWithOptions(t *testing.T) {
+	var query map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = map[string]string{}
+		for k := range r.URL.Query() {
+			query[k] = r.URL.Query().Get(k)
+		}
+		w.Write([]byte(`{"code":0,"data":{"routeSummary":{"extraFee":{"feeAmount":"10","chargeFeeBy":"currency_in","isInBps":true,"feeReceiver":"0xd46B96d15ffF9b2B17e9c788086f3159bD0e8355"}}}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, chain)
+	got, err := client.GetRoutesWithOptions(USDT, sUSDe, "1000000", &GetRoutesOptions{
+		FeeAmount:   "10",
+		ChargeFeeBy: "currency_in",
+		IsInBps:     true,
+		FeeReceiver: "0xd46B96d15ffF9b2B17e9c788086f3159bD0e8355",
+	})
+	if err != nil {
+		t.Fatalf("GetRoutesWithOptions() error = %v", err)
+	}
+
+	want := map[string]string{
+		"feeAmount":   "10",
+		"chargeFeeBy": "currency_in",
+		"isInBps":     "true",
+		"feeReceiver": "0xd46B96d15ffF9b2B17e9c788086f3159bD0e8355",
+	}
+	for k, v := range want {
+		if query[k] != v {
+			t.Errorf("query %s = %q, want %q", k, query[k], v)
+		}
+	}
+
+	if got.Data.RouteSummary.ExtraFee.FeeAmount != "10" {
+		t.Errorf("RouteSummary.ExtraFee = %+v", got.Data.RouteSummary.ExtraFee)
+	}
+}
+
+func TestGetRoutesSourceFilters(t *testing.T) {
+	var query url.Values
+	server, client := NewTestServer(TestHandlers{
+		Routes: func(w http.ResponseWriter, r *http.Request) {
+			query = r.URL.Query()
+			w.Write([]byte(`{"code":0,"data":{"routeSummary":{}}}`))
+		},
+	})
+	defer server.Close()
+
+	if _, err := client.GetRoutesWithOptions(USDT, sUSDe, "1000000", nil); err != nil {
+		t.Fatalf("GetRoutesWithOptions() error = %v", err)
+	}
+	for _, k := range []string{"saveGas", "includedSources", "excludedSources"} {
+		if query.Has(k) {
+			t.Errorf("query %s set without options: %q", k, query.Get(k))
+		}
+	}
+
+	_, err := client.GetRoutesWithOptions(USDT, sUSDe, "1000000", &GetRoutesOptions{
+		SaveGas:         true,
+		IncludedSources: []string{"uniswap", "curve-stable-ng"},
+		ExcludedSources: []string{"kyberswap-limit-order"},
+	})
+	if err != nil {
+		t.Fatalf("GetRoutesWithOptions() error = %v", err)
+	}
+
+	want := map[string]string{
+		"saveGas":         "true",
+		"includedSources": "uniswap,curve-stable-ng",
+		"excludedSources": "kyberswap-limit-order",
+	}
+	for k, v := range want {
+		if query.Get(k) != v {
+			t.Errorf("query %s = %q, want %q", k, query.Get(k), v)
+		}
+	}
+}
+
+func TestBaseURLOverride(t *testing.T) {
+	var hits []string
+	mirror := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits = append(hits, r.URL.Path)
+		w.Write([]byte(`{"code":0,"data":{}}`))
+	}))
+	defer mirror.Close()
+
+	client := NewClient("http://127.0.0.1:1", chain)
+	if _, err := client.GetRoutesWithOptions(USDT, sUSDe, "1000000", &GetRoutesOptions{BaseURL: mirror.URL}); err != nil {
+		t.Fatalf("GetRoutesWithOptions() error = %v", err)
+	}
+
+	sender := "0xd46B96d15ffF9b2B17e9c788086f3159bD0e8355"
+	if _, err := client.BuildRouteCtx(context.Background(), RouteSummary{}, sender, sender, &BuildRouteOptions{BaseURL: mirror.URL}); err != nil {
+		t.Fatalf("BuildRouteCtx() error = %v", err)
+	}
+
+	want := []string{"/" + chain + "/api/v1/routes", "/" + chain + "/api/v1/route/build"}
+	if len(hits) != len(want) || hits[0] != want[0] || hits[1] != want[1] {
+		t.Errorf("mirror paths = %v, want %v", hits, want)
+	}
+	if client.baseURL != "http://127.0.0.1:1" {
+		t.Errorf("client baseURL changed to %s", client.baseURL)
+	}
+}
@@ -43,7 +43,7 @@ func TestKyberSwapClient_GetRoutes(t *testing.T) {
 			args: args{
 				tokenIn:  USDT,
 				tokenOut: sUSDe,
-				amountIn: "2238451.467827",
+				amountIn: "2238451467827",
 			},
 			want:    nil,
 			wantErr: false,
@@ -93,3 +93,24 @@ func TestKyberSwapClient_GetRoutes(t *testing.T) {
 		})
 	}
 }
+
+func TestSetChain_Concurrent(t *testing.T) {
+	server, client := NewTestServer(TestHandlers{})
+	defer server.Close()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 50; i++ {
+			client.SetChain([]string{"ethereum", "arbitrum"}[i%2])
+		}
+	}()
+	for i := 0; i < 50; i++ {
+		client.GetRoutes(USDT, sUSDe, "1000000")
+	}
+	<-done
+
+	if chain := client.Chain(); chain != "arbitrum" {
+		t.Errorf("Chain() = %q, want arbitrum", chain)
+	}
+}
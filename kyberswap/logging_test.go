@@ -0,0 +1,31 @@
+package kyberswap
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+func TestLogging_StructuredAndRedacted(t *testing.T) {
+	var logs bytes.Buffer
+	server, client := NewTestServer(TestHandlers{}, WithLogger(zerolog.New(&logs).Level(zerolog.DebugLevel)))
+	defer server.Close()
+
+	sender := "0xd46B96d15ffF9b2B17e9c788086f3159bD0e8355"
+	if _, err := client.Swap(context.Background(), USDT, sUSDe, "1000000", sender, sender, nil); err != nil {
+		t.Fatalf("Swap() error = %v", err)
+	}
+
+	out := logs.String()
+	for _, field := range []string{`"level":"debug"`, `"endpoint":"routes"`, `"endpoint":"build"`, `"chain":"ethereum"`} {
+		if !strings.Contains(out, field) {
+			t.Errorf("logs missing %s:\n%s", field, out)
+		}
+	}
+	if strings.Contains(strings.ToLower(out), strings.ToLower(sender)) {
+		t.Errorf("logs leak the sender address:\n%s", out)
+	}
+}
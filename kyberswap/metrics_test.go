@@ -0,0 +1,40 @@
+package kyberswap
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+type testRecorder struct {
+	endpoints   []string
+	statusCodes []int
+	errs        []error
+}
+
+func (r *testRecorder) ObserveRequest(endpoint string, dur time.Duration, statusCode int, err error) {
+	r.endpoints = append(r.endpoints, endpoint)
+	r.statusCodes = append(r.statusCodes, statusCode)
+	r.errs = append(r.errs, err)
+}
+
+func TestWithMetrics(t *testing.T) {
+	recorder := &testRecorder{}
+	server, client := NewTestServer(TestHandlers{
+		Build: func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+		},
+	}, WithMetrics(recorder))
+	defer server.Close()
+
+	sender := "0xd46B96d15ffF9b2B17e9c788086f3159bD0e8355"
+	client.Swap(context.Background(), USDT, sUSDe, "1000000", sender, sender, nil)
+
+	if len(recorder.endpoints) != 2 || recorder.endpoints[0] != EndpointRoutes || recorder.endpoints[1] != EndpointBuild {
+		t.Fatalf("endpoints = %v", recorder.endpoints)
+	}
+	if recorder.statusCodes[0] != http.StatusOK || recorder.statusCodes[1] != http.StatusUnprocessableEntity {
+		t.Errorf("status codes = %v", recorder.statusCodes)
+	}
+}
@@ -0,0 +1,123 @@
+package kyberswap
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// minAmounts holds the minimum input amounts GetRoutes checks before
+// calling KyberSwap, which rejects dust amounts with an opaque error
+type minAmounts struct {
+	// raw maps a lowercase token address to its minimum raw amount
+	raw map[string]*big.Int
+	// usd is the minimum USD value of the input, 0 disables the check
+	usd float64
+	// err is the first invalid minimum given, rejected by
+	// NewClientWithOptions
+	err error
+
+	mu sync.Mutex
+	// unitPrices maps chain/token to the USD value of one raw unit, learned
+	// from the routes received
+	unitPrices map[string]float64
+}
+
+// WithMinAmount makes GetRoutes fail with ErrAmountTooSmall, without
+// calling KyberSwap, for amounts of token below amount in raw units. A nil
+// or negative amount makes NewClientWithOptions fail with ErrInvalidAmount.
+func WithMinAmount(token string, amount *big.Int) Option {
+	return func(c *KyberSwapClient) {
+		m := c.minAmountsOrNew()
+		if amount == nil || amount.Sign() < 0 {
+			if m.err == nil {
+				m.err = fmt.Errorf("%w: minimum of %s must not be negative, got %v", ErrInvalidAmount, token, amount)
+			}
+			return
+		}
+		m.raw[strings.ToLower(toKyberToken(token))] = new(big.Int).Set(amount)
+	}
+}
+
+// WithMinAmountUSD makes GetRoutes fail with ErrAmountTooSmall, without
+// calling KyberSwap, for amounts worth less than usd. The value is
+// estimated from the USD price of the input token in the last route
+// received for it on the chain, the first request for a token is always
+// sent. Use it to skip dust balances with no wasted round trip.
+func WithMinAmountUSD(usd float64) Option {
+	return func(c *KyberSwapClient) {
+		c.minAmountsOrNew().usd = usd
+	}
+}
+
+// minAmountsOrNew returns the minimum amounts, creating them on first use
+func (c *KyberSwapClient) minAmountsOrNew() *minAmounts {
+	if c.minAmounts == nil {
+		c.minAmounts = &minAmounts{
+			raw:        make(map[string]*big.Int),
+			unitPrices: make(map[string]float64),
+		}
+	}
+	return c.minAmounts
+}
+
+// check returns ErrAmountTooSmall when amountIn of the normalized tokenIn
+// is below the configured minimums
+func (m *minAmounts) check(chain, tokenIn, amountIn string) error {
+	if m == nil {
+		return nil
+	}
+	amount, ok := new(big.Int).SetString(amountIn, 10)
+	if !ok {
+		return nil
+	}
+
+	if floor, ok := m.raw[tokenIn]; ok && amount.Cmp(floor) < 0 {
+		return fmt.Errorf("%w: %s of %s, minimum %s", ErrAmountTooSmall, amountIn, tokenIn, floor)
+	}
+
+	if m.usd > 0 {
+		m.mu.Lock()
+		unitPrice, ok := m.unitPrices[chain+"/"+tokenIn]
+		m.mu.Unlock()
+		if !ok {
+			return nil
+		}
+		units, _ := new(big.Float).SetInt(amount).Float64()
+		if value := units * unitPrice; value < m.usd {
+			return fmt.Errorf("%w: %s of %s worth $%.4f, minimum $%g", ErrAmountTooSmall, amountIn, tokenIn, value, m.usd)
+		}
+	}
+	return nil
+}
+
+// observe learns the USD price of the input token of summary
+func (m *minAmounts) observe(chain string, summary RouteSummary) {
+	if m == nil || m.usd <= 0 {
+		return
+	}
+
+	usd, err := strconv.ParseFloat(summary.AmountInUsd, 64)
+	if err != nil || !(usd > 0) {
+		return
+	}
+	amount, ok := new(big.Float).SetString(summary.AmountIn)
+	if !ok || amount.Sign() <= 0 {
+		return
+	}
+	units, _ := amount.Float64()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.unitPrices[chain+"/"+strings.ToLower(summary.TokenIn)] = usd / units
+}
+
+// validate returns the first invalid minimum configured
+func (m *minAmounts) validate() error {
+	if m == nil {
+		return nil
+	}
+	return m.err
+}
@@ -0,0 +1,53 @@
+package kyberswap
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strconv"
+	"testing"
+)
+
+func TestWithMinAmount(t *testing.T) {
+	var requests int
+	server, client := NewTestServer(TestHandlers{
+		Routes: func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			// USDT has 6 decimals, one raw unit is worth 1e-6 USD
+			amountIn, _ := strconv.Atoi(r.URL.Query().Get("amountIn"))
+			fmt.Fprintf(w, `{"code":0,"data":{"routeSummary":{"tokenIn":%q,"amountIn":"%d","amountInUsd":"%g","amountOut":"1"}}}`,
+				r.URL.Query().Get("tokenIn"), amountIn, float64(amountIn)/1e6)
+		},
+	}, WithMinAmount(USDT, big.NewInt(50)), WithMinAmountUSD(0.01))
+	defer server.Close()
+
+	if _, err := client.GetRoutes(USDT, sUSDe, "49"); !errors.Is(err, ErrAmountTooSmall) {
+		t.Errorf("GetRoutes(49) error = %v, want ErrAmountTooSmall", err)
+	}
+	if requests != 0 {
+		t.Fatalf("sent %d requests for an amount below the minimum", requests)
+	}
+
+	// the USD price of USDT is unknown until a route for it is received
+	if _, err := client.GetRoutes(USDT, sUSDe, "5000"); err != nil {
+		t.Fatalf("GetRoutes(5000) error = %v", err)
+	}
+	if _, err := client.GetRoutes(USDT, sUSDe, "5000"); !errors.Is(err, ErrAmountTooSmall) {
+		t.Errorf("GetRoutes(5000) error = %v, want ErrAmountTooSmall once priced at $0.005", err)
+	}
+	if _, err := client.GetRoutes(USDT, sUSDe, "10000"); err != nil {
+		t.Errorf("GetRoutes(10000) error = %v", err)
+	}
+	if requests != 2 {
+		t.Errorf("sent %d requests, want 2", requests)
+	}
+}
+
+func TestWithMinAmount_Invalid(t *testing.T) {
+	for _, amount := range []*big.Int{nil, big.NewInt(-1)} {
+		if _, err := NewClientWithOptions(WithMinAmount(USDT, amount)); !errors.Is(err, ErrInvalidAmount) {
+			t.Errorf("NewClientWithOptions(WithMinAmount(%v)) error = %v, want ErrInvalidAmount", amount, err)
+		}
+	}
+}
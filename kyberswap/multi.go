@@ -0,0 +1,39 @@
+package kyberswap
+
+import "sync"
+
+// GetRoutesMulti fetches routes for the pair at each of amounts with at most
+// concurrency requests in flight, e.g. to chart the depth of a pair.
+// Responses and errors are returned in the order of amounts, a failed
+// amount leaves a nil response and doesn't stop the others.
+func (c *KyberSwapClient) GetRoutesMulti(tokenIn, tokenOut string, amounts []string, concurrency int) ([]*RouteResponse, []error) {
+	resps := make([]*RouteResponse, len(amounts))
+	errs := make([]error, len(amounts))
+
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+	if concurrency > len(amounts) {
+		concurrency = len(amounts)
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				resps[i], errs[i] = c.GetRoutes(tokenIn, tokenOut, amounts[i])
+			}
+		}()
+	}
+
+	for i := range amounts {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return resps, errs
+}
@@ -0,0 +1,52 @@
+package kyberswap
+
+import (
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGetRoutesMulti(t *testing.T) {
+	var inFlight, maxInFlight int32
+	server, client := NewTestServer(TestHandlers{
+		Routes: func(w http.ResponseWriter, r *http.Request) {
+			n := atomic.AddInt32(&inFlight, 1)
+			defer atomic.AddInt32(&inFlight, -1)
+			for {
+				m := atomic.LoadInt32(&maxInFlight)
+				if n <= m || atomic.CompareAndSwapInt32(&maxInFlight, m, n) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+
+			amountIn := r.URL.Query().Get("amountIn")
+			fmt.Fprintf(w, `{"code":0,"data":{"routeSummary":{"amountIn":%q}}}`, amountIn)
+		},
+	})
+	defer server.Close()
+
+	amounts := []string{"1000", "10000", "1.5", "100000", "1000000"}
+	resps, errs := client.GetRoutesMulti(USDT, sUSDe, amounts, 2)
+
+	for i, amount := range amounts {
+		if amount == "1.5" {
+			if errs[i] == nil || resps[i] != nil {
+				t.Errorf("GetRoutesMulti()[%d] expected error", i)
+			}
+			continue
+		}
+		if errs[i] != nil {
+			t.Fatalf("GetRoutesMulti()[%d] error = %v", i, errs[i])
+		}
+		if resps[i].Data.RouteSummary.AmountIn != amount {
+			t.Errorf("GetRoutesMulti()[%d] = %s, want %s", i, resps[i].Data.RouteSummary.AmountIn, amount)
+		}
+	}
+
+	if maxInFlight > 2 {
+		t.Errorf("GetRoutesMulti() ran %d requests concurrently, want at most 2", maxInFlight)
+	}
+}
@@ -0,0 +1,42 @@
+package kyberswap
+
+import (
+	"fmt"
+
+	"github.com/ThreeAndTwo/dex-swap-api-helper/internal/drain"
+	"github.com/ThreeAndTwo/dex-swap-api-helper/internal/stats"
+)
+
+// NewMultiChainClient creates one client per chain, configured by opts and
+// keyed by chain name. The clients share a single HTTP client and transport,
+// so the connections to KyberSwap are pooled across chains instead of each
+// client dialing its own. They also share the circuit breaker, metrics
+// recorder and raw capture configured by opts, while Stats and Shutdown
+// apply per chain.
+//
+// Clients created separately can share connections the same way by passing
+// them the same HTTP client with WithHTTPClient.
+func NewMultiChainClient(chains []string, opts ...Option) (map[string]*KyberSwapClient, error) {
+	if len(chains) == 0 {
+		return nil, fmt.Errorf("no chain given")
+	}
+
+	base, err := NewClientWithOptions(append(opts[:len(opts):len(opts)], WithChain(chains[0]))...)
+	if err != nil {
+		return nil, err
+	}
+
+	clients := make(map[string]*KyberSwapClient, len(chains))
+	for _, chain := range chains {
+		if !IsSupportedChain(chain) {
+			return nil, fmt.Errorf("unsupported chain: %q", chain)
+		}
+		client := *base
+		client.chain = newChain(chain)
+		client.sharedHTTP = true
+		client.stats = stats.New()
+		client.drain = drain.New()
+		clients[chain] = &client
+	}
+	return clients, nil
+}
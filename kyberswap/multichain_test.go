@@ -0,0 +1,49 @@
+package kyberswap
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewMultiChainClient(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		w.Write([]byte(cannedRoutesResponse))
+	}))
+	defer server.Close()
+
+	chains := []string{"ethereum", "arbitrum", "base"}
+	clients, err := NewMultiChainClient(chains, WithBaseURL(server.URL), WithTimeouts(Timeouts{Dial: time.Second}))
+	if err != nil {
+		t.Fatalf("NewMultiChainClient() error = %v", err)
+	}
+
+	transport := clients["ethereum"].httpClient.Transport
+	for _, chain := range chains {
+		client := clients[chain]
+		if client.Chain() != chain {
+			t.Errorf("clients[%s].Chain() = %s", chain, client.Chain())
+		}
+		if client.httpClient.Transport != transport {
+			t.Errorf("clients[%s] has its own transport", chain)
+		}
+		if _, err := client.GetRoutes(USDT, sUSDe, "1000000"); err != nil {
+			t.Fatalf("clients[%s].GetRoutes() error = %v", chain, err)
+		}
+	}
+
+	if got := strings.Join(paths, ","); got != "/ethereum/api/v1/routes,/arbitrum/api/v1/routes,/base/api/v1/routes" {
+		t.Errorf("request paths = %s", got)
+	}
+
+	if _, err := NewMultiChainClient([]string{"ethereum", "etherium"}); err == nil {
+		t.Error("NewMultiChainClient() accepted an unknown chain")
+	}
+	if _, err := NewMultiChainClient(nil); err == nil {
+		t.Error("NewMultiChainClient() accepted no chain")
+	}
+}
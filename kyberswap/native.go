@@ -0,0 +1,37 @@
+package kyberswap
+
+import (
+	"fmt"
+
+	"github.com/ThreeAndTwo/dex-swap-api-helper/internal/address"
+)
+
+// NativeToken is the canonical marker for the chain native token, which is
+// also the representation KyberSwap uses
+const NativeToken = address.Native
+
+// IsNativeToken reports whether addr is a native token sentinel, either
+// NativeToken or the zero address
+func IsNativeToken(addr string) bool {
+	return address.IsNative(addr)
+}
+
+// toKyberToken returns the KyberSwap representation of a token address
+func toKyberToken(addr string) string {
+	if IsNativeToken(addr) {
+		return NativeToken
+	}
+	return addr
+}
+
+// validateParty checks addr can send or receive a swap, rejecting the native
+// token sentinels which would send the output to the zero address
+func validateParty(addr string) error {
+	if err := address.Validate(addr); err != nil {
+		return err
+	}
+	if IsNativeToken(addr) {
+		return fmt.Errorf("%w %q: native token sentinel", ErrInvalidAddress, addr)
+	}
+	return nil
+}
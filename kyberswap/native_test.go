@@ -0,0 +1,25 @@
+package kyberswap
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestGetRoutes_NativeToken(t *testing.T) {
+	var tokenIn string
+	server, client := NewTestServer(TestHandlers{
+		Routes: func(w http.ResponseWriter, r *http.Request) {
+			tokenIn = r.URL.Query().Get("tokenIn")
+			w.Write([]byte(cannedRoutesResponse))
+		},
+	})
+	defer server.Close()
+
+	if _, err := client.GetRoutes("0x0000000000000000000000000000000000000000", sUSDe, "1000"); err != nil {
+		t.Fatalf("GetRoutes() error = %v", err)
+	}
+	if tokenIn != strings.ToLower(NativeToken) {
+		t.Errorf("tokenIn = %s, want %s", tokenIn, NativeToken)
+	}
+}
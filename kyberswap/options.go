@@ -0,0 +1,113 @@
+package kyberswap
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// _clientIDHeader is the header identifying the integrator to KyberSwap
+const _clientIDHeader = "X-Client-Id"
+
+// Option configures a KyberSwapClient
+type Option func(*KyberSwapClient)
+
+// NewClientWithOptions creates a new KyberSwap client configured by opts,
+// applied in order on top of the defaults of NewClient. It returns an error
+// if the configured chain isn't supported or a minimum amount is invalid.
+func NewClientWithOptions(opts ...Option) (*KyberSwapClient, error) {
+	c := NewClient("", "")
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if !IsSupportedChain(c.Chain()) {
+		return nil, fmt.Errorf("unsupported chain: %q", c.Chain())
+	}
+	if err := c.minAmounts.validate(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// WithBaseURL sets the KyberSwap API base URL, an empty URL keeps the default
+func WithBaseURL(baseURL string) Option {
+	return func(c *KyberSwapClient) {
+		if baseURL != "" {
+			c.baseURL = baseURL
+		}
+	}
+}
+
+// WithChain sets the chain name the client routes on
+func WithChain(chain string) Option {
+	return func(c *KyberSwapClient) {
+		c.chain.Store(&chain)
+	}
+}
+
+// WithTimeout sets the timeout of the HTTP client
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *KyberSwapClient) {
+		c.ownHTTPClient().Timeout = timeout
+	}
+}
+
+// WithHTTPClient replaces the HTTP client used for requests, e.g. to route
+// through a proxy or customize TLS with a transport of its own:
+//
+//	WithHTTPClient(&http.Client{Transport: &http.Transport{
+//		Proxy:           http.ProxyFromEnvironment,
+//		TLSClientConfig: &tls.Config{RootCAs: proxyCAs},
+//	}})
+//
+// Clients given the same HTTP client share its connection pool, e.g. one
+// client per chain, see NewMultiChainClient. WithTransportConfig,
+// WithTimeouts and WithInsecureTLS give a client a transport of its own,
+// ending the sharing; configure the transport of the shared HTTP client
+// instead to keep it.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *KyberSwapClient) {
+		c.httpClient = httpClient
+		c.sharedHTTP = true
+		c.doer = nil
+	}
+}
+
+// WithLogger sets the logger used by the client instead of the global logger
+func WithLogger(logger zerolog.Logger) Option {
+	return func(c *KyberSwapClient) {
+		c.logger = logger
+	}
+}
+
+// WithClientID sends clientID with every request to identify the integrator
+func WithClientID(clientID string) Option {
+	return func(c *KyberSwapClient) {
+		c.clientID = clientID
+	}
+}
+
+// WithUserAgent sets the User-Agent of every request, an empty ua keeps the
+// default identifying this library and its version
+func WithUserAgent(ua string) Option {
+	return func(c *KyberSwapClient) {
+		if ua != "" {
+			c.userAgent = ua
+		}
+	}
+}
+
+// WithHeader sends the header key with value on every request, e.g.
+// Accept-Language or a header required by a gateway. Calls accumulate, a
+// later call with the same key replaces its value.
+func WithHeader(key, value string) Option {
+	return func(c *KyberSwapClient) {
+		if c.customHeaders == nil {
+			c.customHeaders = make(http.Header)
+		}
+		c.customHeaders.Set(key, value)
+	}
+}
@@ -0,0 +1,103 @@
+package kyberswap
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+func TestNewClientWithOptions(t *testing.T) {
+	var path, clientID string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		clientID = r.Header.Get(_clientIDHeader)
+		w.Write([]byte(`{"code":0,"data":{}}`))
+	}))
+	defer server.Close()
+
+	var logs bytes.Buffer
+	httpClient := &http.Client{}
+	client, err := NewClientWithOptions(
+		WithBaseURL(server.URL),
+		WithChain("base"),
+		WithHTTPClient(httpClient),
+		WithTimeout(3*time.Second),
+		WithLogger(zerolog.New(&logs)),
+		WithClientID("my-app"),
+	)
+	if err != nil {
+		t.Fatalf("NewClientWithOptions() error = %v", err)
+	}
+
+	if client.httpClient.Timeout != 3*time.Second || httpClient.Timeout != 0 {
+		t.Errorf("http client not configured on a copy: %+v, passed %+v", client.httpClient, httpClient)
+	}
+
+	if _, err := client.GetRoutes(USDT, sUSDe, "1000000"); err != nil {
+		t.Fatalf("GetRoutes() error = %v", err)
+	}
+	if path != "/base/api/v1/routes" {
+		t.Errorf("path = %s, want /base/api/v1/routes", path)
+	}
+	if clientID != "my-app" {
+		t.Errorf("%s = %q, want my-app", _clientIDHeader, clientID)
+	}
+	if logs.Len() == 0 {
+		t.Errorf("expected the configured logger to be used")
+	}
+}
+
+func TestNewClientWithOptions_UnknownChain(t *testing.T) {
+	if _, err := NewClientWithOptions(WithChain("etherium")); err == nil {
+		t.Errorf("NewClientWithOptions() expected error for unknown chain")
+	}
+}
+
+func TestWithHeader(t *testing.T) {
+	var got http.Header
+	server, client := NewTestServer(TestHandlers{
+		Routes: func(w http.ResponseWriter, r *http.Request) {
+			got = r.Header
+			w.Write([]byte(cannedRoutesResponse))
+		},
+	}, WithHeader("Accept-Language", "de"), WithHeader("X-Region", "eu"), WithHeader("accept-language", "en-US"))
+	defer server.Close()
+
+	if _, err := client.GetRoutes(USDT, sUSDe, "1000000"); err != nil {
+		t.Fatalf("GetRoutes() error = %v", err)
+	}
+	if got.Get("Accept-Language") != "en-US" || got.Get("X-Region") != "eu" {
+		t.Errorf("headers = %v, want Accept-Language en-US and X-Region eu", got)
+	}
+}
+
+func TestWithUserAgent(t *testing.T) {
+	var userAgent string
+	server, client := NewTestServer(TestHandlers{
+		Routes: func(w http.ResponseWriter, r *http.Request) {
+			userAgent = r.Header.Get("User-Agent")
+			w.Write([]byte(cannedRoutesResponse))
+		},
+	})
+	defer server.Close()
+
+	if _, err := client.GetRoutes(USDT, sUSDe, "1000000"); err != nil {
+		t.Fatalf("GetRoutes() error = %v", err)
+	}
+	if !strings.HasPrefix(userAgent, "dex-swap-api-helper/") {
+		t.Errorf("default User-Agent = %q", userAgent)
+	}
+
+	WithUserAgent("my-bot/1.0")(client)
+	if _, err := client.GetRoutes(USDT, sUSDe, "1000000"); err != nil {
+		t.Fatalf("GetRoutes() error = %v", err)
+	}
+	if userAgent != "my-bot/1.0" {
+		t.Errorf("User-Agent = %q, want my-bot/1.0", userAgent)
+	}
+}
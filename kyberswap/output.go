@@ -0,0 +1,86 @@
+package kyberswap
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ThreeAndTwo/dex-swap-api-helper/units"
+)
+
+// IsOutputDegraded reports whether the output of the build dropped by more
+// than threshold percent compared to the route, e.g. 1 for 1%
+func (r *BuildRouteResponse) IsOutputDegraded(threshold float64) bool {
+	return -r.Data.OutputChange.Percent > threshold
+}
+
+// CheckOutput returns an error wrapping ErrOutputDegraded when the output of
+// the build dropped by more than threshold percent compared to the route
+func (r *BuildRouteResponse) CheckOutput(threshold float64) error {
+	if !r.IsOutputDegraded(threshold) {
+		return nil
+	}
+
+	change := r.Data.OutputChange
+	return fmt.Errorf("%w: output changed by %.4f%% (%s), level %d, threshold %.4f%%",
+		ErrOutputDegraded, change.Percent, change.Amount, change.Level, threshold)
+}
+
+// MinAmountOut returns the minimum amount received for the route with
+// slippageBps of slippage, amountOut * (1 - slippage) rounded down like the
+// router does. It lets the min-out of a built transaction be checked before
+// signing instead of trusting the server.
+func (s *RouteSummary) MinAmountOut(slippageBps int64) (*big.Int, error) {
+	if slippageBps < 0 || slippageBps > 10000 {
+		return nil, fmt.Errorf("invalid slippage: %d bps, must be between 0 and 10000", slippageBps)
+	}
+
+	amountOut, ok := new(big.Int).SetString(s.AmountOut, 10)
+	if !ok || amountOut.Sign() < 0 {
+		return nil, fmt.Errorf("amountOut: %w: %q", ErrInvalidAmount, s.AmountOut)
+	}
+
+	minOut := amountOut.Mul(amountOut, big.NewInt(10000-slippageBps))
+	return minOut.Quo(minOut, big.NewInt(10000)), nil
+}
+
+// TransactionValueBig returns the native token amount, in wei, to send with
+// the built transaction, reading TransactionValue in decimal or 0x prefixed
+// hex. An empty TransactionValue is 0.
+func (r *BuildRouteResponse) TransactionValueBig() (*big.Int, error) {
+	if r.Data.TransactionValue == "" {
+		return new(big.Int), nil
+	}
+
+	value, err := units.ParseQuantity(r.Data.TransactionValue)
+	if err != nil {
+		return nil, fmt.Errorf("transactionValue: %w", err)
+	}
+	return value, nil
+}
+
+// CheckTransactionValue returns an error wrapping ErrUnexpectedValue unless
+// the native value of the built transaction matches tokenIn: zero when
+// swapping from an ERC-20 token, the built amountIn when swapping from the
+// native token
+func (r *BuildRouteResponse) CheckTransactionValue(tokenIn string) error {
+	value, err := r.TransactionValueBig()
+	if err != nil {
+		return err
+	}
+
+	if !IsNativeToken(tokenIn) {
+		if value.Sign() != 0 {
+			return fmt.Errorf("%w: %s wei sent with an ERC-20 input", ErrUnexpectedValue, value)
+		}
+		return nil
+	}
+
+	amountIn, err := units.ParseQuantity(r.Data.AmountIn)
+	if err != nil {
+		return fmt.Errorf("amountIn: %w", err)
+	}
+	if value.Cmp(amountIn) != 0 {
+		return fmt.Errorf("%w: %s wei sent for a native input of %s", ErrUnexpectedValue, value, amountIn)
+	}
+	return nil
+}
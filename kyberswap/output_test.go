@@ -0,0 +1,138 @@
+package kyberswap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestBuildRouteResponse_IsOutputDegraded(t *testing.T) {
+	tests := []struct {
+		percent   float64
+		threshold float64
+		want      bool
+	}{
+		{0, 1, false},
+		{0.5, 1, false},
+		{-0.5, 1, false},
+		{-1, 1, false},
+		{-2, 1, true},
+		{-0.01, 0, true},
+	}
+
+	for _, tt := range tests {
+		var resp BuildRouteResponse
+		resp.Data.OutputChange.Percent = tt.percent
+		if got := resp.IsOutputDegraded(tt.threshold); got != tt.want {
+			t.Errorf("IsOutputDegraded(%v) with %v%% = %v, want %v", tt.threshold, tt.percent, got, tt.want)
+		}
+		if err := resp.CheckOutput(tt.threshold); errors.Is(err, ErrOutputDegraded) != tt.want {
+			t.Errorf("CheckOutput(%v) with %v%% error = %v", tt.threshold, tt.percent, err)
+		}
+	}
+}
+
+func TestBuildRoute_MaxOutputDropPercent(t *testing.T) {
+	server, client := NewTestServer(TestHandlers{
+		Build: func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `{"code":0,"data":{"outputChange":{"amount":"-20000","percent":-2,"level":2}}}`)
+		},
+	})
+	defer server.Close()
+
+	sender := "0xd46B96d15ffF9b2B17e9c788086f3159bD0e8355"
+	if _, err := client.BuildRouteCtx(context.Background(), RouteSummary{}, sender, sender, nil); err != nil {
+		t.Fatalf("BuildRouteCtx() without threshold error = %v", err)
+	}
+
+	_, err := client.BuildRouteCtx(context.Background(), RouteSummary{}, sender, sender, &BuildRouteOptions{MaxOutputDropPercent: 1})
+	if !errors.Is(err, ErrOutputDegraded) {
+		t.Fatalf("BuildRouteCtx() error = %v, want ErrOutputDegraded", err)
+	}
+}
+
+func TestRouteSummary_MinAmountOut(t *testing.T) {
+	tests := []struct {
+		amountOut string
+		slippage  int64
+		want      string
+		wantErr   error
+	}{
+		{amountOut: "874518826958614826", slippage: 10, want: "873644308131656211"},
+		{amountOut: "874518826958614826", slippage: 0, want: "874518826958614826"},
+		{amountOut: "874518826958614826", slippage: 10000, want: "0"},
+		{amountOut: "999", slippage: 50, want: "994"},
+		{amountOut: "1.5", slippage: 10, wantErr: ErrInvalidAmount},
+		{amountOut: "", slippage: 10, wantErr: ErrInvalidAmount},
+	}
+
+	for _, tt := range tests {
+		summary := RouteSummary{AmountOut: tt.amountOut}
+		got, err := summary.MinAmountOut(tt.slippage)
+		if !errors.Is(err, tt.wantErr) {
+			t.Errorf("MinAmountOut(%q, %d) error = %v, want %v", tt.amountOut, tt.slippage, err, tt.wantErr)
+			continue
+		}
+		if err == nil && got.String() != tt.want {
+			t.Errorf("MinAmountOut(%q, %d) = %s, want %s", tt.amountOut, tt.slippage, got, tt.want)
+		}
+	}
+
+	for _, slippage := range []int64{-1, 10001} {
+		if _, err := (&RouteSummary{AmountOut: "1000"}).MinAmountOut(slippage); err == nil {
+			t.Errorf("MinAmountOut(%d) accepted an out of range slippage", slippage)
+		}
+	}
+}
+
+func TestBuildRouteResponse_TransactionValue(t *testing.T) {
+	tests := []struct {
+		name    string
+		tokenIn string
+		value   string
+		wantErr error
+	}{
+		{name: "erc20 zero", tokenIn: USDT, value: "0"},
+		{name: "erc20 empty", tokenIn: USDT, value: ""},
+		{name: "erc20 with value", tokenIn: USDT, value: "0x1", wantErr: ErrUnexpectedValue},
+		{name: "native decimal", tokenIn: NativeToken, value: "1000000"},
+		{name: "native hex", tokenIn: NativeToken, value: "0xf4240"},
+		{name: "native mismatch", tokenIn: NativeToken, value: "999999", wantErr: ErrUnexpectedValue},
+		{name: "invalid", tokenIn: USDT, value: "1.5", wantErr: ErrInvalidAmount},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var resp BuildRouteResponse
+			resp.Data.AmountIn = "1000000"
+			resp.Data.TransactionValue = tt.value
+
+			if err := resp.CheckTransactionValue(tt.tokenIn); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CheckTransactionValue() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	var resp BuildRouteResponse
+	resp.Data.TransactionValue = "0xde0b6b3a7640000"
+	if value, err := resp.TransactionValueBig(); err != nil || value.String() != "1000000000000000000" {
+		t.Errorf("TransactionValueBig() = %v, %v", value, err)
+	}
+}
+
+func TestSwap_UnexpectedValue(t *testing.T) {
+	server, client := NewTestServer(TestHandlers{
+		Build: func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `{"code":0,"data":{"amountIn":"1000000","transactionValue":"1000000"}}`)
+		},
+	})
+	defer server.Close()
+
+	sender := "0xd46B96d15ffF9b2B17e9c788086f3159bD0e8355"
+	_, err := client.Swap(context.Background(), USDT, sUSDe, "1000000", sender, sender, nil)
+	if !errors.Is(err, ErrUnexpectedValue) || !errors.Is(err, ErrBuildRoute) {
+		t.Errorf("Swap() error = %v, want ErrUnexpectedValue", err)
+	}
+}
@@ -0,0 +1,36 @@
+package kyberswap
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// Ping checks KyberSwap is reachable and healthy on the client chain,
+// returning nil when the service is up. It sends a routes request without
+// parameters, which the API rejects cheaply with a 4xx when it is healthy:
+// only transport errors, 5xx responses and rate limiting fail the ping.
+func (c *KyberSwapClient) Ping(ctx context.Context) error {
+	url := fmt.Sprintf("%s/api/v1/routes", c.chainURL())
+	req, err := c.newRequest(ctx, "GET", url, nil)
+	if err != nil {
+		return fmt.Errorf("error creating request: %w", err)
+	}
+
+	resp, err := c.do(EndpointPing, req)
+	if err != nil {
+		return fmt.Errorf("error sending request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("error reading response body: %w", err)
+	}
+
+	if resp.StatusCode >= http.StatusInternalServerError || resp.StatusCode == http.StatusTooManyRequests {
+		return newAPIError(resp.StatusCode, body)
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package kyberswap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestPing(t *testing.T) {
+	status := http.StatusBadRequest
+	server, client := NewTestServer(TestHandlers{
+		Routes: func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(status)
+			fmt.Fprint(w, `{"code":4001,"message":"query parameters are malformed"}`)
+		},
+	})
+	defer server.Close()
+
+	if err := client.Ping(context.Background()); err != nil {
+		t.Fatalf("Ping() error = %v", err)
+	}
+
+	status = http.StatusBadGateway
+	if err := client.Ping(context.Background()); err == nil {
+		t.Errorf("Ping() succeeded on %d", status)
+	}
+
+	status = http.StatusTooManyRequests
+	if err := client.Ping(context.Background()); !errors.Is(err, ErrRateLimited) {
+		t.Errorf("Ping() error = %v, want ErrRateLimited", err)
+	}
+
+	server.Close()
+	if err := client.Ping(context.Background()); err == nil {
+		t.Errorf("Ping() succeeded with the server down")
+	}
+}
@@ -0,0 +1,60 @@
+package kyberswap
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestWithRetry(t *testing.T) {
+	var attempts int
+	var gaps []time.Duration
+	last := time.Now()
+	server, client := NewTestServer(TestHandlers{
+		Routes: func(w http.ResponseWriter, r *http.Request) {
+			attempts++
+			gaps = append(gaps, time.Since(last))
+			last = time.Now()
+
+			if attempts < 3 {
+				w.WriteHeader(http.StatusTooManyRequests)
+				w.Write([]byte(`{"code":4290,"message":"rate limited"}`))
+				return
+			}
+			w.Write([]byte(cannedRoutesResponse))
+		},
+	}, WithRetry(2, 20*time.Millisecond, time.Second))
+	defer server.Close()
+
+	if _, err := client.GetRoutes(USDT, sUSDe, "1000000"); err != nil {
+		t.Fatalf("GetRoutes() error = %v", err)
+	}
+	if attempts != 3 {
+		t.Fatalf("attempts = %d, want 3", attempts)
+	}
+	// without Retry-After the delay doubles: 20ms then 40ms
+	if gaps[1] < 20*time.Millisecond || gaps[2] < 40*time.Millisecond {
+		t.Errorf("retry gaps = %v, want exponential backoff from 20ms", gaps[1:])
+	}
+}
+
+func TestWithRetry_Exhausted(t *testing.T) {
+	var attempts int
+	server, client := NewTestServer(TestHandlers{
+		Routes: func(w http.ResponseWriter, r *http.Request) {
+			attempts++
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"code":4290,"message":"rate limited"}`))
+		},
+	}, WithRetry(2, time.Millisecond, time.Second))
+	defer server.Close()
+
+	if _, err := client.GetRoutes(USDT, sUSDe, "1000000"); !errors.Is(err, ErrRateLimited) {
+		t.Errorf("GetRoutes() error = %v, want %v", err, ErrRateLimited)
+	}
+	if attempts != 3 {
+		t.Errorf("attempts = %d, want the request and 2 retries", attempts)
+	}
+}
@@ -0,0 +1,53 @@
+package kyberswap
+
+import (
+	"fmt"
+	"time"
+)
+
+// Age returns how long ago the routes were received
+func (r *RouteResponse) Age() time.Duration {
+	return time.Since(r.FetchedAt)
+}
+
+// Age returns how long ago the summary was received from GetRoutes, 0 for
+// summaries that weren't
+func (s *RouteSummary) Age() time.Duration {
+	if s.fetchedAt.IsZero() {
+		return 0
+	}
+	return time.Since(s.fetchedAt)
+}
+
+// BlockNumber returns the latest block the pools of the route were read at,
+// 0 when the route carries no block number
+func (s *RouteSummary) BlockNumber() int64 {
+	var block int64
+	for _, path := range s.Route {
+		for _, swap := range path {
+			block = max(block, swap.PoolExtra.BlockNumber)
+		}
+	}
+	return block
+}
+
+// WithMaxRouteAge makes BuildRoute reject route summaries received from
+// GetRoutes more than maxAge ago with ErrStaleRoute, 0 disables the check
+func WithMaxRouteAge(maxAge time.Duration) Option {
+	return func(c *KyberSwapClient) {
+		c.maxAge = maxAge
+	}
+}
+
+// checkRouteAge returns ErrStaleRoute when summary is older than the
+// configured max age
+func (c *KyberSwapClient) checkRouteAge(summary RouteSummary) error {
+	if c.maxAge <= 0 {
+		return nil
+	}
+
+	if age := summary.Age(); age > c.maxAge {
+		return fmt.Errorf("%w: route fetched %s ago, max age %s", ErrStaleRoute, age.Round(time.Millisecond), c.maxAge)
+	}
+	return nil
+}
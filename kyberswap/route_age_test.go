@@ -0,0 +1,39 @@
+package kyberswap
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRouteResponse_Age(t *testing.T) {
+	server, client := NewTestServer(TestHandlers{}, WithMaxRouteAge(20*time.Millisecond))
+	defer server.Close()
+
+	routeResp, err := client.GetRoutes(USDT, sUSDe, "1000000")
+	if err != nil {
+		t.Fatalf("GetRoutes() error = %v", err)
+	}
+	if routeResp.FetchedAt.IsZero() || routeResp.Age() > time.Second {
+		t.Errorf("FetchedAt = %v", routeResp.FetchedAt)
+	}
+	if got := routeResp.Data.RouteSummary.BlockNumber(); got != 21000000 {
+		t.Errorf("BlockNumber() = %d, want 21000000", got)
+	}
+
+	sender := "0xd46B96d15ffF9b2B17e9c788086f3159bD0e8355"
+	if _, err := client.BuildRoute(routeResp.Data.RouteSummary, sender, sender); err != nil {
+		t.Fatalf("BuildRoute() fresh route error = %v", err)
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	_, err = client.BuildRouteCtx(context.Background(), routeResp.Data.RouteSummary, sender, sender, nil)
+	if !errors.Is(err, ErrStaleRoute) {
+		t.Fatalf("BuildRouteCtx() stale route error = %v, want ErrStaleRoute", err)
+	}
+
+	if _, err := client.BuildRoute(RouteSummary{}, sender, sender); err != nil {
+		t.Errorf("BuildRoute() of a summary not from GetRoutes error = %v", err)
+	}
+}
@@ -0,0 +1,59 @@
+package kyberswap
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestClose(t *testing.T) {
+	server, client := NewTestServer(TestHandlers{})
+	defer server.Close()
+
+	if _, err := client.GetRoutes(USDT, sUSDe, "1000000"); err != nil {
+		t.Fatalf("GetRoutes() error = %v", err)
+	}
+	if err := client.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if _, err := client.GetRoutes(USDT, sUSDe, "1000000"); !errors.Is(err, ErrClientClosed) {
+		t.Errorf("GetRoutes() after Close error = %v, want ErrClientClosed", err)
+	}
+	if err := client.Shutdown(context.Background()); err != nil {
+		t.Errorf("Shutdown() after Close error = %v", err)
+	}
+}
+
+// idleCloseCounter is a transport counting the CloseIdleConnections calls
+type idleCloseCounter struct {
+	http.RoundTripper
+	closes int
+}
+
+func (t *idleCloseCounter) CloseIdleConnections() {
+	t.closes++
+}
+
+func TestClose_SharedHTTPClient(t *testing.T) {
+	transport := &idleCloseCounter{RoundTripper: http.DefaultTransport}
+	clients, err := NewMultiChainClient([]string{"ethereum", "base"})
+	if err != nil {
+		t.Fatalf("NewMultiChainClient() error = %v", err)
+	}
+	shared, err := NewClientWithOptions(WithHTTPClient(&http.Client{Transport: transport}))
+	if err != nil {
+		t.Fatalf("NewClientWithOptions() error = %v", err)
+	}
+
+	shared.Close()
+	shared.Shutdown(context.Background())
+	if transport.closes != 0 {
+		t.Errorf("idle connections of the shared HTTP client closed %d times, want 0", transport.closes)
+	}
+	for chain, client := range clients {
+		if !client.sharedHTTP {
+			t.Errorf("%s client owns the HTTP client shared by NewMultiChainClient", chain)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package kyberswap
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestWithRequestSigner(t *testing.T) {
+	var signed []string
+	var buildBody string
+	server, client := NewTestServer(TestHandlers{
+		Build: func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			buildBody = string(body)
+			if r.Header.Get("X-Signature") != buildBody {
+				t.Error("build request signed over a different body")
+			}
+			w.Write([]byte(cannedBuildResponse))
+		},
+	}, WithRequestSigner(func(req *http.Request, body []byte) error {
+		signed = append(signed, req.URL.Path)
+		req.Header.Set("X-Signature", string(body))
+		return nil
+	}))
+	defer server.Close()
+
+	routes, err := client.GetRoutes(USDT, sUSDe, "1000000")
+	if err != nil {
+		t.Fatalf("GetRoutes() error = %v", err)
+	}
+	sender := "0xd46B96d15ffF9b2B17e9c788086f3159bD0e8355"
+	if _, err := client.BuildRoute(routes.Data.RouteSummary, sender, sender); err != nil {
+		t.Fatalf("BuildRoute() error = %v", err)
+	}
+	if len(signed) != 2 || buildBody == "" {
+		t.Errorf("signed %v, want the routes and build requests", signed)
+	}
+}
+
+func TestWithRequestSigner_Error(t *testing.T) {
+	errSign := errors.New("no key")
+	server, client := NewTestServer(TestHandlers{
+		Routes: func(w http.ResponseWriter, r *http.Request) {
+			t.Error("request sent despite the signer error")
+		},
+	}, WithRequestSigner(func(req *http.Request, body []byte) error {
+		return errSign
+	}))
+	defer server.Close()
+
+	if _, err := client.GetRoutes(USDT, sUSDe, "1000000"); !errors.Is(err, errSign) {
+		t.Errorf("GetRoutes() error = %v, want the signer error", err)
+	}
+}
@@ -0,0 +1,45 @@
+package kyberswap
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestStats(t *testing.T) {
+	server, client := NewTestServer(TestHandlers{
+		Build: func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		},
+	})
+	defer server.Close()
+
+	routeResp, err := client.GetRoutes(USDT, sUSDe, "1000000")
+	if err != nil {
+		t.Fatalf("GetRoutes() error = %v", err)
+	}
+	client.BuildRoute(routeResp.Data.RouteSummary, "0xd46B96d15ffF9b2B17e9c788086f3159bD0e8355", "")
+
+	stats := client.Stats()
+	if routes := stats[EndpointRoutes]; routes.Requests != 1 || routes.Errors != 0 || routes.LatencyEMA <= 0 {
+		t.Errorf("Stats()[routes] = %+v", routes)
+	}
+	if build := stats[EndpointBuild]; build.Requests != 1 || build.Errors != 1 {
+		t.Errorf("Stats()[build] = %+v", build)
+	}
+}
+
+func TestNewMultiChainClient_Stats(t *testing.T) {
+	server, _ := NewTestServer(TestHandlers{})
+	defer server.Close()
+
+	clients, err := NewMultiChainClient([]string{"ethereum", "arbitrum"}, WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewMultiChainClient() error = %v", err)
+	}
+	if _, err := clients["ethereum"].GetRoutes(USDT, sUSDe, "1000000"); err != nil {
+		t.Fatalf("GetRoutes() error = %v", err)
+	}
+	if got := clients["arbitrum"].Stats()[EndpointRoutes].Requests; got != 0 {
+		t.Errorf("arbitrum routes requests = %d, want 0", got)
+	}
+}
@@ -0,0 +1,97 @@
+package kyberswap
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/ThreeAndTwo/dex-swap-api-helper/internal/codec"
+)
+
+// routeSummaryFields has the fields of RouteSummary without its JSON methods
+type routeSummaryFields RouteSummary
+
+// UnmarshalJSON decodes the summary and keeps its raw bytes, so BuildRoute
+// can forward the summary exactly as KyberSwap sent it
+func (s *RouteSummary) UnmarshalJSON(data []byte) error {
+	return s.unmarshalWith(codec.Standard, data)
+}
+
+func (s *RouteSummary) unmarshalWith(jsonCodec Codec, data []byte) error {
+	var fields routeSummaryFields
+	if err := jsonCodec.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	*s = RouteSummary(fields)
+	s.raw = append(json.RawMessage(nil), data...)
+	return nil
+}
+
+// MarshalJSON encodes a decoded summary as the raw bytes it was decoded
+// from. Re-encoding the decoded fields would alter the free form pool
+// extras, reordering keys and rounding large numbers to float64, which the
+// build endpoint rejects as an invalid route. Changes made to the fields of a
+// decoded summary are therefore not sent.
+func (s RouteSummary) MarshalJSON() ([]byte, error) {
+	return s.marshalWith(codec.Standard)
+}
+
+func (s RouteSummary) marshalWith(jsonCodec Codec) ([]byte, error) {
+	if s.raw != nil {
+		return s.raw, nil
+	}
+	return jsonCodec.Marshal(routeSummaryFields(s))
+}
+
+// unmarshalWith decodes the response, decoding its route summary with
+// jsonCodec as well
+func (r *RouteResponse) unmarshalWith(jsonCodec Codec, data []byte) error {
+	type fields RouteResponse
+	var route struct {
+		fields
+		Data struct {
+			RouteSummary  json.RawMessage `json:"routeSummary"`
+			RouterAddress string          `json:"routerAddress"`
+		} `json:"data"`
+	}
+	if err := jsonCodec.Unmarshal(data, &route); err != nil {
+		return err
+	}
+
+	*r = RouteResponse(route.fields)
+	r.Data.RouterAddress = route.Data.RouterAddress
+	if len(route.Data.RouteSummary) > 0 {
+		return r.Data.RouteSummary.unmarshalWith(jsonCodec, route.Data.RouteSummary)
+	}
+	return nil
+}
+
+// GasBig returns the gas estimate of the route
+func (s *RouteSummary) GasBig() (*big.Int, error) {
+	return parseBig("gas", s.Gas)
+}
+
+// GasPriceBig returns the gas price the route was estimated with, in wei
+func (s *RouteSummary) GasPriceBig() (*big.Int, error) {
+	return parseBig("gasPrice", s.GasPrice)
+}
+
+// GasUSDFloat returns the gas cost of the route in USD
+func (s *RouteSummary) GasUSDFloat() (float64, error) {
+	gas, err := strconv.ParseFloat(s.GasUsd, 64)
+	if err != nil {
+		return 0, fmt.Errorf("error parsing gasUsd: %w", err)
+	}
+	return gas, nil
+}
+
+// parseBig parses the non negative integer value of the summary field name
+func parseBig(name, value string) (*big.Int, error) {
+	n, ok := new(big.Int).SetString(value, 10)
+	if !ok || n.Sign() < 0 {
+		return nil, fmt.Errorf("%s: %w: %q", name, ErrInvalidAmount, value)
+	}
+	return n, nil
+}
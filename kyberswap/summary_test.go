@@ -0,0 +1,84 @@
+package kyberswap
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestRouteSummary_RoundTrip(t *testing.T) {
+	summary := `{"tokenIn":"0xdac17f958d2ee523a2206206994597c13d831ec7","amountIn":"1000000","route":[[{"pool":"0x1",` +
+		`"poolType":"uniswap-v3","extra":{"nextStateSqrtPriceX96":79228162514264337593543950336,"b":1,"a":2}}]]}`
+
+	var build string
+	server, client := NewTestServer(TestHandlers{
+		Routes: func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintf(w, `{"code":0,"data":{"routeSummary":%s}}`, summary)
+		},
+		Build: func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			build = string(body)
+			w.Write([]byte(cannedBuildResponse))
+		},
+	})
+	defer server.Close()
+
+	routeResp, err := client.GetRoutes(USDT, sUSDe, "1000000")
+	if err != nil {
+		t.Fatalf("GetRoutes() error = %v", err)
+	}
+	if routeResp.Data.RouteSummary.AmountIn != "1000000" {
+		t.Errorf("decoded summary = %+v", routeResp.Data.RouteSummary)
+	}
+
+	sender := "0xd46B96d15ffF9b2B17e9c788086f3159bD0e8355"
+	if _, err := client.BuildRouteCtx(context.Background(), routeResp.Data.RouteSummary, sender, sender, nil); err != nil {
+		t.Fatalf("BuildRouteCtx() error = %v", err)
+	}
+	if !strings.Contains(build, `"routeSummary":`+summary) {
+		t.Errorf("build request doesn't forward the summary verbatim:\n%s", build)
+	}
+}
+
+func TestRouteSummary_MarshalConstructed(t *testing.T) {
+	data, err := json.Marshal(RouteSummary{TokenIn: USDT, AmountIn: "1"})
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	if !strings.Contains(string(data), `"tokenIn":"`+USDT+`"`) || !strings.Contains(string(data), `"amountIn":"1"`) {
+		t.Errorf("json.Marshal() = %s", data)
+	}
+}
+
+func TestRouteSummary_Gas(t *testing.T) {
+	summary := RouteSummary{Gas: "235623", GasPrice: "6270000000", GasUsd: "3.78"}
+
+	gas, err := summary.GasBig()
+	if err != nil || gas.Int64() != 235623 {
+		t.Errorf("GasBig() = %v, %v", gas, err)
+	}
+	gasPrice, err := summary.GasPriceBig()
+	if err != nil || gasPrice.Int64() != 6270000000 {
+		t.Errorf("GasPriceBig() = %v, %v", gasPrice, err)
+	}
+	gasUsd, err := summary.GasUSDFloat()
+	if err != nil || gasUsd != 3.78 {
+		t.Errorf("GasUSDFloat() = %v, %v", gasUsd, err)
+	}
+
+	invalid := RouteSummary{Gas: "1.5", GasPrice: "-1", GasUsd: "n/a"}
+	if _, err := invalid.GasBig(); !errors.Is(err, ErrInvalidAmount) {
+		t.Errorf("GasBig() error = %v, want ErrInvalidAmount", err)
+	}
+	if _, err := invalid.GasPriceBig(); !errors.Is(err, ErrInvalidAmount) {
+		t.Errorf("GasPriceBig() error = %v, want ErrInvalidAmount", err)
+	}
+	if _, err := invalid.GasUSDFloat(); err == nil {
+		t.Error("GasUSDFloat() error = nil")
+	}
+}
@@ -0,0 +1,45 @@
+package kyberswap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrGetRoutes marks Swap errors raised while fetching the route
+	ErrGetRoutes = errors.New("kyberswap: get routes failed")
+	// ErrBuildRoute marks Swap errors raised while building the route
+	ErrBuildRoute = errors.New("kyberswap: build route failed")
+)
+
+// SwapOptions holds the optional parameters of both steps of Swap
+type SwapOptions struct {
+	Routes *GetRoutesOptions
+	Build  *BuildRouteOptions
+}
+
+// Swap fetches the best route for the pair and builds it for sender and
+// recipient. Errors wrap ErrGetRoutes or ErrBuildRoute depending on the
+// failing step, along with the underlying error. A built transaction whose
+// native value doesn't match tokenIn fails with ErrUnexpectedValue.
+func (c *KyberSwapClient) Swap(ctx context.Context, tokenIn, tokenOut, amountIn, sender, recipient string, opts *SwapOptions) (*BuildRouteResponse, error) {
+	if opts == nil {
+		opts = &SwapOptions{}
+	}
+
+	routeResp, err := c.GetRoutesCtx(ctx, tokenIn, tokenOut, amountIn, opts.Routes)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrGetRoutes, err)
+	}
+
+	buildResp, err := c.BuildRouteCtx(ctx, routeResp.Data.RouteSummary, sender, recipient, opts.Build)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrBuildRoute, err)
+	}
+	if err := buildResp.CheckTransactionValue(tokenIn); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrBuildRoute, err)
+	}
+
+	return buildResp, nil
+}
@@ -0,0 +1,81 @@
+package kyberswap
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestKyberSwapClient_Swap(t *testing.T) {
+	sender := "0xd46B96d15ffF9b2B17e9c788086f3159bD0e8355"
+	deadline := time.Now().Add(time.Minute).Truncate(time.Second)
+
+	tests := []struct {
+		name        string
+		routeStatus int
+		buildStatus int
+		wantErr     error
+	}{
+		{name: "success", routeStatus: http.StatusOK, buildStatus: http.StatusOK},
+		{name: "no route", routeStatus: http.StatusBadRequest, buildStatus: http.StatusOK, wantErr: ErrGetRoutes},
+		{name: "build failure", routeStatus: http.StatusOK, buildStatus: http.StatusBadRequest, wantErr: ErrBuildRoute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var built BuildRouteRequest
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/ethereum/api/v1/routes":
+					w.WriteHeader(tt.routeStatus)
+					if tt.routeStatus != http.StatusOK {
+						w.Write([]byte(`{"code":4008,"message":"route not found"}`))
+						return
+					}
+					w.Write([]byte(`{"code":0,"data":{"routeSummary":{"tokenIn":"` + USDT + `","amountOut":"99"}}}`))
+				case "/ethereum/api/v1/route/build":
+					json.NewDecoder(r.Body).Decode(&built)
+					w.WriteHeader(tt.buildStatus)
+					w.Write([]byte(`{"code":0,"data":{"amountOut":"99"}}`))
+				}
+			}))
+			defer server.Close()
+
+			client := NewClient(server.URL, chain)
+			got, err := client.Swap(context.Background(), USDT, sUSDe, "100", sender, sender, &SwapOptions{
+				Build: &BuildRouteOptions{SlippageTolerance: 50, Deadline: deadline},
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Swap() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+
+			if got.Data.AmountOut != "99" {
+				t.Errorf("Swap() amountOut = %s", got.Data.AmountOut)
+			}
+			if built.SlippageTolerance != 50 || built.Deadline != deadline.Unix() || built.RouteSummary.TokenIn != USDT {
+				t.Errorf("build request = %+v", built)
+			}
+		})
+	}
+}
+
+func TestKyberSwapClient_SwapNoRoute(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"code":4008,"message":"route not found"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, chain)
+	_, err := client.Swap(context.Background(), USDT, sUSDe, "100", "", "", nil)
+	if !errors.Is(err, ErrNoRoute) {
+		t.Errorf("Swap() error = %v, want ErrNoRoute", err)
+	}
+}
@@ -0,0 +1,20 @@
+package kyberswap
+
+import (
+	"context"
+	"testing"
+)
+
+func TestNewTestServer(t *testing.T) {
+	server, client := NewTestServer(TestHandlers{}, WithChain("arbitrum"))
+	defer server.Close()
+
+	sender := "0xd46B96d15ffF9b2B17e9c788086f3159bD0e8355"
+	resp, err := client.Swap(context.Background(), USDT, sUSDe, "1000000", sender, sender, nil)
+	if err != nil {
+		t.Fatalf("Swap() error = %v", err)
+	}
+	if resp.Data.RouterAddress == "" || resp.Data.Data == "" {
+		t.Errorf("Swap() = %+v", resp)
+	}
+}
@@ -0,0 +1,28 @@
+package kyberswap
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestWithTimeouts(t *testing.T) {
+	client, err := NewClientWithOptions(WithTimeouts(Timeouts{
+		Dial:           time.Second,
+		ResponseHeader: 5 * time.Second,
+	}))
+	if err != nil {
+		t.Fatalf("NewClientWithOptions() error = %v", err)
+	}
+
+	transport, ok := client.httpClient.Transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("transport = %T, want *http.Transport", client.httpClient.Transport)
+	}
+	if transport.ResponseHeaderTimeout != 5*time.Second || transport.DialContext == nil {
+		t.Errorf("transport = %+v", transport)
+	}
+	if client.httpClient.Timeout != 10*time.Second {
+		t.Errorf("client timeout = %v, want the 10s default kept", client.httpClient.Timeout)
+	}
+}
@@ -0,0 +1,54 @@
+package kyberswap
+
+import (
+	"net/http"
+	"time"
+)
+
+// WithTransportConfig sizes the idle connection pool of the HTTP client,
+// e.g. raising maxIdleConnsPerHost above the default of 2 that throttles
+// bursts of concurrent calls. maxIdleConns caps the idle connections across
+// hosts and idleTimeout closes connections idle for longer, a zero value
+// keeps the current setting. The transport of the HTTP client is replaced
+// by a configured copy, with a connection pool of its own.
+func WithTransportConfig(maxIdleConns, maxIdleConnsPerHost int, idleTimeout time.Duration) Option {
+	return func(c *KyberSwapClient) {
+		transport := c.ownTransport()
+
+		if maxIdleConns > 0 {
+			transport.MaxIdleConns = maxIdleConns
+		}
+		if maxIdleConnsPerHost > 0 {
+			transport.MaxIdleConnsPerHost = maxIdleConnsPerHost
+		}
+		if idleTimeout > 0 {
+			transport.IdleConnTimeout = idleTimeout
+		}
+	}
+}
+
+// ownHTTPClient replaces the HTTP client with a shallow copy and returns
+// it, so options can configure the client without modifying one passed to
+// WithHTTPClient and shared with other clients
+func (c *KyberSwapClient) ownHTTPClient() *http.Client {
+	httpClient := *c.httpClient
+	c.httpClient = &httpClient
+	return c.httpClient
+}
+
+// ownTransport replaces the transport of the HTTP client with a clone,
+// of http.DefaultTransport when it isn't an *http.Transport, and returns it
+// for options to configure. The clone has a connection pool of its own, so
+// a client configured this way no longer shares connections with the other
+// clients given the same HTTP client.
+func (c *KyberSwapClient) ownTransport() *http.Transport {
+	httpClient := c.ownHTTPClient()
+	transport, ok := httpClient.Transport.(*http.Transport)
+	if !ok || transport == nil {
+		transport = http.DefaultTransport.(*http.Transport)
+	}
+	transport = transport.Clone()
+	httpClient.Transport = transport
+	c.sharedHTTP = false
+	return transport
+}
@@ -0,0 +1,68 @@
+package kyberswap
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestGetRoutes_Addresses(t *testing.T) {
+	var tokenIn string
+	server, client := NewTestServer(TestHandlers{
+		Routes: func(w http.ResponseWriter, r *http.Request) {
+			tokenIn = r.URL.Query().Get("tokenIn")
+			w.Write([]byte(cannedRoutesResponse))
+		},
+	})
+	defer server.Close()
+
+	if _, err := client.GetRoutes(wstETH, ezETH, "1000"); err != nil {
+		t.Fatalf("GetRoutes() error = %v", err)
+	}
+	if tokenIn != strings.ToLower(wstETH) {
+		t.Errorf("tokenIn = %s, want lowercase %s", tokenIn, wstETH)
+	}
+
+	if _, err := client.GetRoutes("0xdac17f958d2ee523a2206206994597c13d831e", ezETH, "1000"); !errors.Is(err, ErrInvalidAddress) {
+		t.Errorf("GetRoutes() error = %v, want ErrInvalidAddress", err)
+	}
+}
+
+func TestBuildRoute_Addresses(t *testing.T) {
+	server, client := NewTestServer(TestHandlers{})
+	defer server.Close()
+
+	for _, tt := range []struct{ sender, recipient string }{
+		{sender: "0xd46B96d15ffF9b2B17e9c788086f3159bD0e8355", recipient: "not-an-address"},
+		{sender: "0xd46B96d15ffF9b2B17e9c788086f3159bD0e8355", recipient: "0x0000000000000000000000000000000000000000"},
+		{sender: NativeToken, recipient: "0xd46B96d15ffF9b2B17e9c788086f3159bD0e8355"},
+		{sender: "", recipient: ""},
+	} {
+		_, err := client.BuildRouteCtx(context.Background(), RouteSummary{}, tt.sender, tt.recipient, nil)
+		if !errors.Is(err, ErrInvalidAddress) {
+			t.Errorf("BuildRouteCtx(%q, %q) error = %v, want ErrInvalidAddress", tt.sender, tt.recipient, err)
+		}
+	}
+}
+
+func TestBuildRoute_DefaultRecipient(t *testing.T) {
+	var req BuildRouteRequest
+	server, client := NewTestServer(TestHandlers{
+		Build: func(w http.ResponseWriter, r *http.Request) {
+			json.NewDecoder(r.Body).Decode(&req)
+			w.Write([]byte(cannedBuildResponse))
+		},
+	})
+	defer server.Close()
+
+	sender := "0xd46B96d15ffF9b2B17e9c788086f3159bD0e8355"
+	if _, err := client.BuildRoute(RouteSummary{}, sender, ""); err != nil {
+		t.Fatalf("BuildRoute() error = %v", err)
+	}
+	if req.Recipient != sender {
+		t.Errorf("recipient = %q, want sender %q", req.Recipient, sender)
+	}
+}
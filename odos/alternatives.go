@@ -0,0 +1,94 @@
+package odos
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// QuoteCriterion selects the quote SelectQuote picks among candidates
+type QuoteCriterion int
+
+const (
+	// ByNetOut picks the highest NetOutValue, the output value net of gas
+	ByNetOut QuoteCriterion = iota
+	// ByLeastGas picks the lowest GasEstimate
+	ByLeastGas
+	// ByFewestHops picks the path with the fewest hops, read from the path
+	// visualization: the candidates must be quoted with PathViz set
+	ByFewestHops
+)
+
+// QuoteCandidate is a quote of QuoteAlternatives with the request it was
+// quoted with
+type QuoteCandidate struct {
+	Request *QuoteRequest
+	Quote   *QuoteResponse
+}
+
+// QuoteAlternatives quotes req as given and with Simple toggled
+// concurrently, returning the candidate paths to compare, e.g. to offer a
+// simpler path costing less gas next to the best one. The Odos quote
+// endpoint returns a single path per request, the alternatives come from
+// the differing requests. Candidates that failed to quote are left out, an
+// error is only returned when none quoted.
+func (c *OdosClient) QuoteAlternatives(req *QuoteRequest) ([]QuoteCandidate, error) {
+	alternative := *req
+	alternative.Simple = !req.Simple
+	requests := []*QuoteRequest{req, &alternative}
+
+	quotes := make([]*QuoteResponse, len(requests))
+	errs := make([]error, len(requests))
+	var wg sync.WaitGroup
+	for i, request := range requests {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			quotes[i], errs[i] = c.Quote(request)
+		}()
+	}
+	wg.Wait()
+
+	var candidates []QuoteCandidate
+	for i, quote := range quotes {
+		if errs[i] == nil {
+			candidates = append(candidates, QuoteCandidate{Request: requests[i], Quote: quote})
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("failed to quote alternatives: %w", errors.Join(errs...))
+	}
+	return candidates, nil
+}
+
+// SelectQuote returns the candidate best by criterion, ties going to the
+// higher NetOutValue then to the earlier candidate. It returns false when
+// candidates is empty.
+func SelectQuote(candidates []QuoteCandidate, by QuoteCriterion) (QuoteCandidate, bool) {
+	if len(candidates) == 0 {
+		return QuoteCandidate{}, false
+	}
+
+	best := candidates[0]
+	for _, candidate := range candidates[1:] {
+		if better(candidate.Quote, best.Quote, by) {
+			best = candidate
+		}
+	}
+	return best, true
+}
+
+// better reports whether quote beats best by criterion
+func better(quote, best *QuoteResponse, by QuoteCriterion) bool {
+	switch by {
+	case ByLeastGas:
+		if quote.GasEstimate != best.GasEstimate {
+			return quote.GasEstimate < best.GasEstimate
+		}
+	case ByFewestHops:
+		if hops, bestHops := quote.PathViz.Hops(), best.PathViz.Hops(); hops != bestHops {
+			return hops < bestHops
+		}
+	}
+	return quote.NetOutValue > best.NetOutValue
+}
@@ -0,0 +1,51 @@
+package odos
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestQuoteAlternatives(t *testing.T) {
+	server, client := NewTestServer(TestHandlers{
+		Quote: func(w http.ResponseWriter, r *http.Request) {
+			var req QuoteRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			// the simple path is one hop costing less gas for a lower output
+			if req.Simple {
+				fmt.Fprint(w, `{"pathId":"simple","gasEstimate":120000,"netOutValue":99.1,`+
+					`"pathViz":{"nodes":[{"symbol":"DAI"},{"symbol":"sUSDe"}],"links":[{"source":0,"target":1}]}}`)
+				return
+			}
+			fmt.Fprint(w, `{"pathId":"best","gasEstimate":310000,"netOutValue":99.6,`+
+				`"pathViz":{"nodes":[{"symbol":"DAI"},{"symbol":"USDC"},{"symbol":"sUSDe"}],"links":[{"source":0,"target":1},{"source":1,"target":2}]}}`)
+		},
+	})
+	defer server.Close()
+
+	req := NewQuoteRequest(1, []InputToken{{TokenAddress: DAI, Amount: "1000"}}, nil).SetSingleOutput(sUSDe).WithUserAddr(testUserAddr).WithPathViz(true)
+	candidates, err := client.QuoteAlternatives(req)
+	if err != nil {
+		t.Fatalf("QuoteAlternatives() error = %v", err)
+	}
+	if len(candidates) != 2 || candidates[0].Request != req || !candidates[1].Request.Simple || req.Simple {
+		t.Fatalf("QuoteAlternatives() = %+v", candidates)
+	}
+
+	for _, tt := range []struct {
+		by   QuoteCriterion
+		want string
+	}{
+		{ByNetOut, "best"},
+		{ByLeastGas, "simple"},
+		{ByFewestHops, "simple"},
+	} {
+		if got, ok := SelectQuote(candidates, tt.by); !ok || got.Quote.PathId != tt.want {
+			t.Errorf("SelectQuote(%d) = %s, want %s", tt.by, got.Quote.PathId, tt.want)
+		}
+	}
+	if _, ok := SelectQuote(nil, ByNetOut); ok {
+		t.Error("SelectQuote(nil) ok = true")
+	}
+}
@@ -0,0 +1,80 @@
+package odos
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/ThreeAndTwo/dex-swap-api-helper/internal/codec"
+)
+
+// InAmountsInt returns InAmounts as integers
+func (q *QuoteResponse) InAmountsInt() ([]*big.Int, error) {
+	return parseAmounts(q.InAmounts)
+}
+
+// OutAmountsInt returns OutAmounts as integers
+func (q *QuoteResponse) OutAmountsInt() ([]*big.Int, error) {
+	return parseAmounts(q.OutAmounts)
+}
+
+// InputAmounts returns the amounts of InputTokens as integers
+func (a *AssembleResponse) InputAmounts() ([]*big.Int, error) {
+	raw := make([]string, len(a.InputTokens))
+	for i, token := range a.InputTokens {
+		raw[i] = token.Amount
+	}
+	return parseAmounts(raw)
+}
+
+// OutputAmounts returns the amounts of OutputTokens as integers
+func (a *AssembleResponse) OutputAmounts() ([]*big.Int, error) {
+	raw := make([]string, len(a.OutputTokens))
+	for i, token := range a.OutputTokens {
+		raw[i] = token.Amount
+	}
+	return parseAmounts(raw)
+}
+
+// parseAmounts parses raw integer amounts without the int64 overflow of
+// 18 decimal token amounts
+func parseAmounts(raw []string) ([]*big.Int, error) {
+	amounts := make([]*big.Int, len(raw))
+	for i, s := range raw {
+		amount, ok := new(big.Int).SetString(s, 10)
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidAmount, s)
+		}
+		amounts[i] = amount
+	}
+	return amounts, nil
+}
+
+// UnmarshalJSON decodes the simulation, reading AmountsOut from JSON numbers
+// or strings without going through int64 or float64
+func (s *Simulation) UnmarshalJSON(data []byte) error {
+	return s.unmarshalWith(codec.Standard, data)
+}
+
+func (s *Simulation) unmarshalWith(jsonCodec Codec, data []byte) error {
+	type fields Simulation
+	var sim struct {
+		fields
+		AmountsOut []json.Number `json:"amountsOut"`
+	}
+	if err := jsonCodec.Unmarshal(data, &sim); err != nil {
+		return err
+	}
+
+	*s = Simulation(sim.fields)
+	s.AmountsOut = nil
+	for _, amount := range sim.AmountsOut {
+		s.AmountsOut = append(s.AmountsOut, amount.String())
+	}
+	return nil
+}
+
+// AmountsOutInt returns AmountsOut as integers
+func (s *Simulation) AmountsOutInt() ([]*big.Int, error) {
+	return parseAmounts(s.AmountsOut)
+}
@@ -0,0 +1,66 @@
+package odos
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestAssembleResponse_Amounts(t *testing.T) {
+	var resp AssembleResponse
+	if err := json.Unmarshal([]byte(cannedAssembleResponse), &resp); err != nil {
+		t.Fatal(err)
+	}
+	resp.OutputTokens[0].Amount = "50000000000000000000000" // overflows int64
+
+	in, err := resp.InputAmounts()
+	if err != nil || len(in) != 1 || in[0].String() != "1000000000000000000" {
+		t.Errorf("InputAmounts() = %v, %v", in, err)
+	}
+	out, err := resp.OutputAmounts()
+	if err != nil || len(out) != 1 || out[0].String() != "50000000000000000000000" {
+		t.Errorf("OutputAmounts() = %v, %v", out, err)
+	}
+
+	resp.OutputTokens[0].Amount = "1.5"
+	if _, err := resp.OutputAmounts(); !errors.Is(err, ErrInvalidAmount) {
+		t.Errorf("OutputAmounts() error = %v, want ErrInvalidAmount", err)
+	}
+}
+
+func TestQuoteResponse_Amounts(t *testing.T) {
+	var resp QuoteResponse
+	if err := json.Unmarshal([]byte(cannedQuoteResponse), &resp); err != nil {
+		t.Fatal(err)
+	}
+
+	in, err := resp.InAmountsInt()
+	if err != nil || in[0].String() != "1000000000000000000" {
+		t.Errorf("InAmountsInt() = %v, %v", in, err)
+	}
+	out, err := resp.OutAmountsInt()
+	if err != nil || out[0].String() != "874518826958614826" {
+		t.Errorf("OutAmountsInt() = %v, %v", out, err)
+	}
+}
+
+func TestSimulation_AmountsOut(t *testing.T) {
+	var sim Simulation
+	data := `{"isSuccess":true,"amountsOut":[50000000000000000000000,"874518826958614826"],"gasEstimate":235623,"simulationError":""}`
+	if err := json.Unmarshal([]byte(data), &sim); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+
+	if !sim.IsSuccess || sim.GasEstimate != 235623 {
+		t.Errorf("Simulation = %+v", sim)
+	}
+	want := []string{"50000000000000000000000", "874518826958614826"}
+	if len(sim.AmountsOut) != 2 || sim.AmountsOut[0] != want[0] || sim.AmountsOut[1] != want[1] {
+		t.Errorf("AmountsOut = %v, want %v", sim.AmountsOut, want)
+	}
+
+	amounts, err := sim.AmountsOutInt()
+	if err != nil || amounts[0].String() != want[0] {
+		t.Errorf("AmountsOutInt() = %v, %v", amounts, err)
+	}
+}
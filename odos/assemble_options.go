@@ -0,0 +1,64 @@
+package odos
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ThreeAndTwo/dex-swap-api-helper/internal/idempotency"
+)
+
+// AssembleOptions holds the optional parameters of AssembleWithOptions
+type AssembleOptions struct {
+	// Requote is the request the path id was quoted with. A path that
+	// expired before it could be assembled is quoted again from it and the
+	// fresh path assembled instead, at most once.
+	Requote *QuoteRequest
+	// IdempotencyKey is sent in the Idempotency-Key header so the provider
+	// or a gateway can dedupe a repeated assemble, e.g. when the caller
+	// retries after a timeout. A random key is generated when empty.
+	IdempotencyKey string
+}
+
+// idempotencyKey returns the configured key, a new random one when unset
+func (o *AssembleOptions) idempotencyKey() string {
+	if o == nil || o.IdempotencyKey == "" {
+		return idempotency.NewKey()
+	}
+	return o.IdempotencyKey
+}
+
+// requote returns the request to quote again on path expiry, nil when unset
+func (o *AssembleOptions) requote() *QuoteRequest {
+	if o == nil {
+		return nil
+	}
+	return o.Requote
+}
+
+// AssembleWithOptions assembles pathId like Assemble with the configured
+// options. When the path expired and opts carry the originating request, it
+// quotes again once and assembles the new path, the returned error then
+// names both the expired and the requoted path.
+func (c *OdosClient) AssembleWithOptions(userAddr, pathId string, isSimulate bool, opts *AssembleOptions) (*AssembleResponse, error) {
+	assembleResp, _, err := c.assemble(userAddr, pathId, isSimulate, opts.idempotencyKey())
+	req := opts.requote()
+	if req == nil || !errors.Is(err, ErrPathExpired) {
+		return assembleResp, err
+	}
+
+	c.logger.Warn().Str("path_id", pathId).Msg("path expired before assemble, quoting again")
+
+	quoteResp, err := c.Quote(req)
+	if err != nil {
+		return nil, fmt.Errorf("path %s expired, failed to quote again: %w", pathId, err)
+	}
+	if quoteResp.PathId == "" {
+		return nil, fmt.Errorf("path %s expired: %w", pathId, ErrEmptyPathId)
+	}
+
+	assembleResp, err = c.AssembleQuote(userAddr, quoteResp, isSimulate)
+	if err != nil {
+		return nil, fmt.Errorf("path %s expired, failed to assemble requoted path %s: %w", pathId, quoteResp.PathId, err)
+	}
+	return assembleResp, nil
+}
@@ -0,0 +1,88 @@
+package odos
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAssembleWithOptions_Requote(t *testing.T) {
+	tests := []struct {
+		name          string
+		opts          *AssembleOptions
+		expiredTimes  int
+		wantErr       error
+		wantQuotes    int
+		wantAssembles int
+	}{
+		{name: "fresh path", opts: &AssembleOptions{Requote: NewQuoteRequest(1, nil, nil)}, wantAssembles: 1},
+		{name: "expired without options", opts: nil, expiredTimes: 1, wantErr: ErrPathExpired, wantAssembles: 1},
+		{name: "expired once", opts: &AssembleOptions{Requote: NewQuoteRequest(1, nil, nil)}, expiredTimes: 1, wantQuotes: 1, wantAssembles: 2},
+		{name: "expired twice", opts: &AssembleOptions{Requote: NewQuoteRequest(1, nil, nil)}, expiredTimes: 2, wantErr: ErrPathExpired, wantQuotes: 1, wantAssembles: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var quotes, assembles int
+			server, client := NewTestServer(TestHandlers{
+				Quote: func(w http.ResponseWriter, r *http.Request) {
+					quotes++
+					w.Write([]byte(`{"pathId":"fresh"}`))
+				},
+				Assemble: func(w http.ResponseWriter, r *http.Request) {
+					assembles++
+					if assembles <= tt.expiredTimes {
+						w.WriteHeader(http.StatusBadRequest)
+						w.Write([]byte(`{"detail":"Path not found or expired","traceId":"t","errorCode":4001}`))
+						return
+					}
+					w.Write([]byte(`{"transaction":{}}`))
+				},
+			})
+			defer server.Close()
+
+			_, err := client.AssembleWithOptions(testUserAddr, "stale", false, tt.opts)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("AssembleWithOptions() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantQuotes > 0 && err != nil && !strings.Contains(err.Error(), "path stale expired") {
+				t.Errorf("AssembleWithOptions() error = %q, want it to name the expired path", err)
+			}
+			if quotes != tt.wantQuotes || assembles != tt.wantAssembles {
+				t.Errorf("quotes = %d, assembles = %d, want %d, %d", quotes, assembles, tt.wantQuotes, tt.wantAssembles)
+			}
+		})
+	}
+}
+
+func TestAssembleWithOptions_IdempotencyKey(t *testing.T) {
+	var keys []string
+	server, client := NewTestServer(TestHandlers{
+		Assemble: func(w http.ResponseWriter, r *http.Request) {
+			keys = append(keys, r.Header.Get("Idempotency-Key"))
+			if len(keys) == 1 {
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+			w.Write([]byte(cannedAssembleResponse))
+		},
+	}, WithRetry(1, time.Millisecond, time.Millisecond))
+	defer server.Close()
+
+	if _, err := client.AssembleWithOptions(testUserAddr, "path", false, &AssembleOptions{IdempotencyKey: "swap-42"}); err != nil {
+		t.Fatalf("AssembleWithOptions() error = %v", err)
+	}
+	if len(keys) != 2 || keys[0] != "swap-42" || keys[1] != "swap-42" {
+		t.Errorf("Idempotency-Key = %q, want swap-42 on the request and its retry", keys)
+	}
+
+	keys = keys[:1]
+	if _, err := client.Assemble(testUserAddr, "path", false); err != nil {
+		t.Fatalf("Assemble() error = %v", err)
+	}
+	if len(keys) != 2 || keys[1] == "" || keys[1] == "swap-42" {
+		t.Errorf("Idempotency-Key = %q, want a generated key", keys[1:])
+	}
+}
@@ -0,0 +1,38 @@
+package odos
+
+import "sync"
+
+// QuoteBatch runs the quotes with at most concurrency requests in flight.
+// Responses and errors are returned in the order of reqs, a failed quote
+// leaves a nil response and doesn't stop the others.
+func (c *OdosClient) QuoteBatch(reqs []*QuoteRequest, concurrency int) ([]*QuoteResponse, []error) {
+	resps := make([]*QuoteResponse, len(reqs))
+	errs := make([]error, len(reqs))
+
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+	if concurrency > len(reqs) {
+		concurrency = len(reqs)
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				resps[i], errs[i] = c.Quote(reqs[i])
+			}
+		}()
+	}
+
+	for i := range reqs {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return resps, errs
+}
@@ -0,0 +1,63 @@
+package odos
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestQuoteBatch(t *testing.T) {
+	var inFlight, maxInFlight int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			m := atomic.LoadInt32(&maxInFlight)
+			if n <= m || atomic.CompareAndSwapInt32(&maxInFlight, m, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+
+		var req QuoteRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.OutputTokens[0].TokenAddress == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"detail":"Invalid output token","errorCode":4000}`))
+			return
+		}
+		json.NewEncoder(w).Encode(QuoteResponse{OutTokens: []string{req.OutputTokens[0].TokenAddress}})
+	}))
+	defer server.Close()
+
+	outputs := []string{DAI, sUSDe, "", wstETH, ezETH}
+	reqs := make([]*QuoteRequest, len(outputs))
+	for i, out := range outputs {
+		reqs[i] = NewQuoteRequest(1, nil, []OutputToken{{TokenAddress: out, Proportion: 1}})
+	}
+
+	client := NewClient(server.URL)
+	resps, errs := client.QuoteBatch(reqs, 2)
+
+	for i, out := range outputs {
+		if out == "" {
+			if errs[i] == nil || resps[i] != nil {
+				t.Errorf("QuoteBatch()[%d] expected error", i)
+			}
+			continue
+		}
+		if errs[i] != nil {
+			t.Fatalf("QuoteBatch()[%d] error = %v", i, errs[i])
+		}
+		if resps[i].OutTokens[0] != out {
+			t.Errorf("QuoteBatch()[%d] = %s, want %s", i, resps[i].OutTokens[0], out)
+		}
+	}
+
+	if maxInFlight > 2 {
+		t.Errorf("QuoteBatch() ran %d requests concurrently, want at most 2", maxInFlight)
+	}
+}
@@ -0,0 +1,33 @@
+package odos
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/ThreeAndTwo/dex-swap-api-helper/internal/breaker"
+)
+
+// ErrCircuitOpen is returned without sending the request while the circuit
+// breaker set by WithCircuitBreaker is open
+var ErrCircuitOpen = breaker.ErrOpen
+
+// WithCircuitBreaker fast-fails calls with ErrCircuitOpen after
+// failureThreshold consecutive failures, transport errors and 5xx responses,
+// until cooldown elapsed and a probe call succeeds
+func WithCircuitBreaker(failureThreshold int, cooldown time.Duration) Option {
+	return func(c *OdosClient) {
+		c.breaker = breaker.New(failureThreshold, cooldown)
+	}
+}
+
+// CircuitOpen reports whether the circuit breaker set by WithCircuitBreaker
+// currently fast-fails calls, always false without one
+func (c *OdosClient) CircuitOpen() bool {
+	return c.breaker != nil && c.breaker.Open()
+}
+
+// succeeded reports whether a call counts as a success for the circuit
+// breaker, only server side failures count against the provider
+func succeeded(resp *http.Response, err error) bool {
+	return err == nil && resp.StatusCode < http.StatusInternalServerError
+}
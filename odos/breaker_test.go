@@ -0,0 +1,42 @@
+package odos
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestWithCircuitBreaker(t *testing.T) {
+	var calls int
+	recorder := &testRecorder{}
+	server, client := NewTestServer(TestHandlers{
+		Price: func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			w.WriteHeader(http.StatusServiceUnavailable)
+		},
+	}, WithCircuitBreaker(2, time.Hour), WithMetrics(recorder))
+	defer server.Close()
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetTokenPrice(chainId, DAI); errors.Is(err, ErrCircuitOpen) {
+			t.Fatalf("GetTokenPrice() #%d error = %v before the threshold", i, err)
+		}
+	}
+
+	_, err := client.GetTokenPrice(chainId, DAI)
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("GetTokenPrice() error = %v, want ErrCircuitOpen", err)
+	}
+	if calls != 2 {
+		t.Errorf("server called %d times, want 2", calls)
+	}
+
+	last := recorder.observations[len(recorder.observations)-1]
+	if len(recorder.observations) != 3 || !errors.Is(last.err, ErrCircuitOpen) || last.statusCode != 0 {
+		t.Errorf("observations = %+v, want the rejected call last", recorder.observations)
+	}
+	if stats := client.Stats()[EndpointTokenPrice]; stats.Requests != 2 || stats.Rejected != 1 {
+		t.Errorf("Stats() = %+v, want 2 requests and 1 rejected", stats)
+	}
+}
@@ -0,0 +1,114 @@
+package odos
+
+import "github.com/ThreeAndTwo/dex-swap-api-helper/chains"
+
+const (
+	// DefaultSlippageLimitPercent is the slippage used by NewQuoteRequest, matching the Odos default
+	DefaultSlippageLimitPercent = 0.3
+)
+
+// NewQuoteRequest creates a quote request with the required fields set and
+// the following defaults:
+//   - SlippageLimitPercent: DefaultSlippageLimitPercent
+//   - Compact: true, the assembled calldata uses the cheaper compact encoding
+//   - Simple, DisableRFQs: false
+//   - LikeAsset: false, enabling it for uncorrelated pairs worsens the
+//     quote. See WithLikeAsset and WithLikeAssetDetection
+//   - PathViz: false, it bloats and slows down the response. Use GetPathViz
+//     to fetch the visualization only when it is displayed
+//   - empty source/pool blacklists and whitelists
+func NewQuoteRequest(chainID chains.ChainID, in []InputToken, out []OutputToken) *QuoteRequest {
+	return &QuoteRequest{
+		ChainId:              chainID,
+		InputTokens:          in,
+		OutputTokens:         out,
+		SlippageLimitPercent: DefaultSlippageLimitPercent,
+		SourceBlacklist:      []string{},
+		SourceWhitelist:      []string{},
+		PoolBlacklist:        []string{},
+		Compact:              true,
+	}
+}
+
+// AddInput adds an input token, combining several inputs into the outputs
+// of the quote
+func (r *QuoteRequest) AddInput(tokenAddr, amount string) *QuoteRequest {
+	r.InputTokens = append(r.InputTokens, InputToken{
+		TokenAddress: tokenAddr,
+		Amount:       amount,
+	})
+	return r
+}
+
+// SetSingleOutput replaces the outputs with tokenAddr receiving the whole
+// output of the quote
+func (r *QuoteRequest) SetSingleOutput(tokenAddr string) *QuoteRequest {
+	r.OutputTokens = []OutputToken{
+		{
+			TokenAddress: tokenAddr,
+			Proportion:   1,
+		},
+	}
+	return r
+}
+
+// AddOutput adds an output token receiving proportion of the output of the
+// quote, e.g. 0.25 for a quarter. The proportions of all outputs must sum to
+// 1, which Validate checks before the quote is sent.
+func (r *QuoteRequest) AddOutput(tokenAddr string, proportion float64) *QuoteRequest {
+	r.OutputTokens = append(r.OutputTokens, OutputToken{
+		TokenAddress: tokenAddr,
+		Proportion:   proportion,
+	})
+	return r
+}
+
+// WithUserAddr sets the address that will execute the swap
+func (r *QuoteRequest) WithUserAddr(userAddr string) *QuoteRequest {
+	r.UserAddr = userAddr
+	return r
+}
+
+// WithGasPrice sets the gas price in gwei used to compute net output
+func (r *QuoteRequest) WithGasPrice(gasPrice float64) *QuoteRequest {
+	r.GasPrice = gasPrice
+	return r
+}
+
+// WithSlippageLimitPercent sets the slippage in percent, 0.5 means 0.5%
+func (r *QuoteRequest) WithSlippageLimitPercent(percent float64) *QuoteRequest {
+	r.SlippageLimitPercent = percent
+	return r
+}
+
+// WithSimple asks Odos for a less complex path, trading some output for a
+// quicker response
+func (r *QuoteRequest) WithSimple(simple bool) *QuoteRequest {
+	r.Simple = simple
+	return r
+}
+
+// WithCompact selects the compact calldata encoding for the assembled
+// transaction, which lowers calldata gas
+func (r *QuoteRequest) WithCompact(compact bool) *QuoteRequest {
+	r.Compact = compact
+	return r
+}
+
+// WithDisableRFQs excludes RFQ (market maker) liquidity from the path
+func (r *QuoteRequest) WithDisableRFQs(disable bool) *QuoteRequest {
+	r.DisableRFQs = disable
+	return r
+}
+
+// WithPathViz includes the path visualization in the quote response
+func (r *QuoteRequest) WithPathViz(pathViz bool) *QuoteRequest {
+	r.PathViz = pathViz
+	return r
+}
+
+// WithReferralCode sets the partner referral code of the quote
+func (r *QuoteRequest) WithReferralCode(code int) *QuoteRequest {
+	r.ReferralCode = code
+	return r
+}
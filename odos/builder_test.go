@@ -0,0 +1,41 @@
+package odos
+
+import "testing"
+
+func TestNewQuoteRequest(t *testing.T) {
+	req := NewQuoteRequest(1,
+		[]InputToken{{TokenAddress: DAI, Amount: "1000000000000000000"}},
+		[]OutputToken{{TokenAddress: sUSDe, Proportion: 1}},
+	)
+
+	if req.SlippageLimitPercent != DefaultSlippageLimitPercent {
+		t.Errorf("SlippageLimitPercent = %v, want %v", req.SlippageLimitPercent, DefaultSlippageLimitPercent)
+	}
+	if !req.Compact || req.Simple || req.DisableRFQs || req.PathViz {
+		t.Errorf("unexpected default flags: %+v", req)
+	}
+	if req.SourceBlacklist == nil || req.SourceWhitelist == nil || req.PoolBlacklist == nil {
+		t.Errorf("expected empty lists instead of nil")
+	}
+
+	req.WithSimple(true).WithCompact(false).WithDisableRFQs(true).WithPathViz(true).WithUserAddr("0x163A5EC5e9C32238d075E2D829fE9fA87451e3b7")
+	if !req.Simple || req.Compact || !req.DisableRFQs || !req.PathViz || req.UserAddr == "" {
+		t.Errorf("setters not applied: %+v", req)
+	}
+}
+
+func TestQuoteRequest_MultiInput(t *testing.T) {
+	req := NewQuoteRequest(1, nil, nil).
+		AddInput(DAI, "1000000000000000000").
+		AddInput(sUSDe, "2000000000000000000").
+		SetSingleOutput(wstETH)
+
+	if len(req.InputTokens) != 2 || req.InputTokens[1].TokenAddress != sUSDe {
+		t.Errorf("InputTokens = %+v", req.InputTokens)
+	}
+
+	req.SetSingleOutput(ezETH)
+	if len(req.OutputTokens) != 1 || req.OutputTokens[0].TokenAddress != ezETH || req.OutputTokens[0].Proportion != 1 {
+		t.Errorf("OutputTokens = %+v", req.OutputTokens)
+	}
+}
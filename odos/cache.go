@@ -0,0 +1,85 @@
+package odos
+
+import (
+	"sync"
+	"time"
+)
+
+// ttlCache memoizes values for ttl, bounded to maxEntries
+type ttlCache[V any] struct {
+	mu         sync.Mutex
+	ttl        time.Duration
+	maxEntries int
+	entries    map[string]cachedEntry[V]
+}
+
+type cachedEntry[V any] struct {
+	value     V
+	fetchedAt time.Time
+}
+
+func newTTLCache[V any](ttl time.Duration, maxEntries int) *ttlCache[V] {
+	return &ttlCache[V]{
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		entries:    make(map[string]cachedEntry[V]),
+	}
+}
+
+func (tc *ttlCache[V]) get(key string) (*V, bool) {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+
+	entry, ok := tc.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if time.Since(entry.fetchedAt) >= tc.ttl {
+		delete(tc.entries, key)
+		return nil, false
+	}
+
+	value := entry.value
+	return &value, true
+}
+
+func (tc *ttlCache[V]) set(key string, value V) {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+
+	if _, ok := tc.entries[key]; !ok && len(tc.entries) >= tc.maxEntries {
+		tc.evict()
+	}
+	tc.entries[key] = cachedEntry[V]{value: value, fetchedAt: time.Now()}
+}
+
+// evict drops the expired entries, or the oldest one if none has expired
+func (tc *ttlCache[V]) evict() {
+	var oldestKey string
+	var oldest time.Time
+	for key, entry := range tc.entries {
+		if time.Since(entry.fetchedAt) >= tc.ttl {
+			delete(tc.entries, key)
+			continue
+		}
+		if oldestKey == "" || entry.fetchedAt.Before(oldest) {
+			oldestKey, oldest = key, entry.fetchedAt
+		}
+	}
+
+	if len(tc.entries) >= tc.maxEntries {
+		delete(tc.entries, oldestKey)
+	}
+}
+
+func (tc *ttlCache[V]) delete(key string) {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	delete(tc.entries, key)
+}
+
+func (tc *ttlCache[V]) clear() {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	tc.entries = make(map[string]cachedEntry[V])
+}
@@ -0,0 +1,57 @@
+package odos
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Selectors of the swap functions of the Odos router
+const (
+	SelectorSwap             = "0x3b635ce4" // swap((address,uint256,address,address,uint256,uint256,address),bytes,address,uint32)
+	SelectorSwapCompact      = "0x83bd37f9" // swapCompact()
+	SelectorSwapMulti        = "0x7bf2d6d4" // swapMulti((address,uint256,address)[],(address,uint256,address)[],uint256,bytes,address,uint32)
+	SelectorSwapMultiCompact = "0x84a7f3dd" // swapMultiCompact()
+	SelectorSwapPermit2      = "0x87b621b5" // swapPermit2((address,uint256,uint256,bytes),(address,uint256,address,address,uint256,uint256,address),bytes,address,uint32)
+)
+
+// ErrUnexpectedSelector is returned when assembled calldata doesn't call the
+// expected router function
+var ErrUnexpectedSelector = errors.New("odos: unexpected calldata selector")
+
+var (
+	compactSelectors  = map[string]bool{SelectorSwapCompact: true, SelectorSwapMultiCompact: true}
+	standardSelectors = map[string]bool{SelectorSwap: true, SelectorSwapMulti: true, SelectorSwapPermit2: true}
+)
+
+// Selector returns the function selector of the calldata, lowercase with
+// the 0x prefix, or an empty string when the calldata is shorter than a
+// selector
+func (t *Transaction) Selector() string {
+	data := strings.TrimPrefix(strings.ToLower(t.Data), "0x")
+	if len(data) < 8 {
+		return ""
+	}
+	return "0x" + data[:8]
+}
+
+// IsCompact reports whether the calldata uses the compact encoding selected
+// with QuoteRequest.Compact, which calls swapCompact or swapMultiCompact
+// with the parameters packed after the selector
+func (t *Transaction) IsCompact() bool {
+	return compactSelectors[t.Selector()]
+}
+
+// ValidateSelector checks the calldata calls a swap function of the Odos
+// router with the requested encoding, returning an error wrapping
+// ErrUnexpectedSelector otherwise
+func (t *Transaction) ValidateSelector(compact bool) error {
+	selector := t.Selector()
+	switch {
+	case compact && compactSelectors[selector], !compact && standardSelectors[selector]:
+		return nil
+	case compactSelectors[selector], standardSelectors[selector]:
+		return fmt.Errorf("%w: %s, want the compact encoding %v", ErrUnexpectedSelector, selector, compact)
+	}
+	return fmt.Errorf("%w: %q isn't an Odos router swap", ErrUnexpectedSelector, selector)
+}
@@ -0,0 +1,39 @@
+package odos
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestTransaction_Selector(t *testing.T) {
+	tests := []struct {
+		data        string
+		wantCompact bool
+		wantErr     bool // ValidateSelector(wantCompact)
+	}{
+		{data: "0x83bd37f90001", wantCompact: true},
+		{data: "0x84A7F3DD", wantCompact: true},
+		{data: "0x3b635ce4000000", wantCompact: false},
+		{data: "0x7bf2d6d4", wantCompact: false},
+		{data: "0xa9059cbb0000", wantCompact: false, wantErr: true},
+		{data: "0x83bd", wantCompact: false, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		tx := Transaction{Data: tt.data}
+		if got := tx.IsCompact(); got != tt.wantCompact {
+			t.Errorf("IsCompact(%s) = %v, want %v", tt.data, got, tt.wantCompact)
+		}
+		if err := tx.ValidateSelector(tt.wantCompact); (err != nil) != tt.wantErr {
+			t.Errorf("ValidateSelector(%s, %v) error = %v, wantErr %v", tt.data, tt.wantCompact, err, tt.wantErr)
+		}
+	}
+
+	compact := Transaction{Data: "0x83bd37f9"}
+	if err := compact.ValidateSelector(false); !errors.Is(err, ErrUnexpectedSelector) {
+		t.Errorf("ValidateSelector(false) on compact calldata error = %v, want %v", err, ErrUnexpectedSelector)
+	}
+	if got := compact.Selector(); got != SelectorSwapCompact {
+		t.Errorf("Selector() = %s, want %s", got, SelectorSwapCompact)
+	}
+}
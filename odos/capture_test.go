@@ -0,0 +1,49 @@
+package odos
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+type capturedCall struct {
+	endpoint string
+	reqBody  string
+	respBody string
+}
+
+func TestWithRawCapture(t *testing.T) {
+	var calls []capturedCall
+	server, client := NewTestServer(TestHandlers{}, WithRawCapture(func(endpoint string, reqBody, respBody []byte) {
+		calls = append(calls, capturedCall{endpoint, string(reqBody), string(respBody)})
+	}))
+	defer server.Close()
+
+	resp, err := client.Swap(NewQuoteRequest(1, nil, nil).AddInput(DAI, "1000000000000000000").SetSingleOutput(sUSDe).WithUserAddr(testUserAddr), false)
+	if err != nil {
+		t.Fatalf("Swap() error = %v", err)
+	}
+	if resp.Transaction.Data != "0x83bd37f9" {
+		t.Errorf("response decoded after capture = %+v", resp.Transaction)
+	}
+
+	if len(calls) != 2 {
+		t.Fatalf("captured %d calls, want 2", len(calls))
+	}
+	if calls[0].endpoint != EndpointQuote || calls[0].respBody != cannedQuoteResponse {
+		t.Errorf("quote capture = %+v", calls[0])
+	}
+	var sent QuoteRequest
+	if err := json.Unmarshal([]byte(calls[0].reqBody), &sent); err != nil || sent.UserAddr != testUserAddr {
+		t.Errorf("captured quote request = %s", calls[0].reqBody)
+	}
+	if calls[1].endpoint != EndpointAssemble || calls[1].respBody != cannedAssembleResponse {
+		t.Errorf("assemble capture = %+v", calls[1])
+	}
+
+	server.Close()
+	calls = nil
+	client.GetTokenPrice(chainId, DAI)
+	if len(calls) != 1 || calls[0].reqBody != "" || calls[0].respBody != "" {
+		t.Errorf("transport failure capture = %+v", calls)
+	}
+}
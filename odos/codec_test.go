@@ -0,0 +1,46 @@
+package odos
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// countingCodec wraps encoding/json, counting the calls
+type countingCodec struct {
+	marshals, unmarshals int
+}
+
+func (c *countingCodec) Marshal(v interface{}) ([]byte, error) {
+	c.marshals++
+	return json.Marshal(v)
+}
+
+func (c *countingCodec) Unmarshal(data []byte, v interface{}) error {
+	c.unmarshals++
+	return json.Unmarshal(data, v)
+}
+
+func TestWithJSONCodec(t *testing.T) {
+	jsonCodec := &countingCodec{}
+	server, client := NewTestServer(TestHandlers{}, WithJSONCodec(jsonCodec))
+	defer server.Close()
+
+	quote, err := client.Quote(NewQuoteRequest(1, []InputToken{{TokenAddress: DAI, Amount: "1000"}}, nil).SetSingleOutput(sUSDe).WithUserAddr(testUserAddr))
+	if err != nil {
+		t.Fatalf("Quote() error = %v", err)
+	}
+	if quote.PathId == "" {
+		t.Error("Quote() decoded no path id")
+	}
+	assembled, err := client.Assemble(testUserAddr, quote.PathId, true)
+	if err != nil {
+		t.Fatalf("Assemble() error = %v", err)
+	}
+	if assembled.Transaction.Gas != 353434 || len(assembled.Simulation.AmountsOut) == 0 {
+		t.Errorf("Assemble() decoded transaction %+v, simulation %+v", assembled.Transaction, assembled.Simulation)
+	}
+	// the assembled transaction and simulation are decoded with the codec too
+	if jsonCodec.marshals != 2 || jsonCodec.unmarshals != 4 {
+		t.Errorf("codec marshals = %d, unmarshals = %d, want 2 and 4", jsonCodec.marshals, jsonCodec.unmarshals)
+	}
+}
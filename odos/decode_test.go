@@ -0,0 +1,92 @@
+package odos
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+)
+
+func TestQuote_BadBodies(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		wantErr error
+		wantMsg string
+	}{
+		{name: "empty", body: "", wantErr: ErrEmptyResponse, wantMsg: "empty response body (status 200)"},
+		{name: "whitespace", body: " \n", wantErr: ErrEmptyResponse},
+		{name: "truncated", body: `{"pathId":"9c2294c5`, wantErr: ErrTruncatedResponse},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, client := NewTestServer(TestHandlers{
+				Quote: func(w http.ResponseWriter, r *http.Request) {
+					w.Write([]byte(tt.body))
+				},
+			})
+			defer server.Close()
+
+			_, err := client.Quote(NewQuoteRequest(1, nil, nil))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Quote() error = %v, want %v", err, tt.wantErr)
+			}
+			if !strings.Contains(err.Error(), tt.wantMsg) {
+				t.Errorf("Quote() error = %q, want it to contain %q", err, tt.wantMsg)
+			}
+		})
+	}
+}
+
+func TestDecodeBody_InvalidJSON(t *testing.T) {
+	var v QuoteResponse
+	c := NewClient("")
+	err := c.decodeBody(http.StatusOK, []byte(`{"pathId":}`), &v)
+	if err == nil || errors.Is(err, ErrTruncatedResponse) || errors.Is(err, ErrEmptyResponse) {
+		t.Errorf("decodeBody() error = %v, want a plain syntax error", err)
+	}
+}
+
+func TestQuote_CompressedBody(t *testing.T) {
+	var encoded bytes.Buffer
+	bw := brotli.NewWriter(&encoded)
+	bw.Write([]byte(cannedQuoteResponse))
+	bw.Close()
+
+	server, client := NewTestServer(TestHandlers{
+		Quote: func(w http.ResponseWriter, r *http.Request) {
+			if enc := r.Header.Get("Accept-Encoding"); enc != "" && enc != "gzip" {
+				t.Errorf("Accept-Encoding = %q, want the transport default", enc)
+			}
+			w.Header().Set("Content-Encoding", "br")
+			w.Write(encoded.Bytes())
+		},
+	})
+	defer server.Close()
+
+	got, err := client.Quote(NewQuoteRequest(1, nil, nil))
+	if err != nil {
+		t.Fatalf("Quote() error = %v", err)
+	}
+	if got.PathId == "" {
+		t.Errorf("Quote() = %+v, want the canned quote", got)
+	}
+}
+
+func TestQuote_UnsupportedEncoding(t *testing.T) {
+	server, client := NewTestServer(TestHandlers{
+		Quote: func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Encoding", "zstd")
+			w.Write([]byte(cannedQuoteResponse))
+		},
+	})
+	defer server.Close()
+
+	if _, err := client.Quote(NewQuoteRequest(1, nil, nil)); !errors.Is(err, ErrUnsupportedEncoding) {
+		t.Errorf("Quote() error = %v, want %v", err, ErrUnsupportedEncoding)
+	}
+}
@@ -0,0 +1,42 @@
+package odos
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// doerFunc adapts a function to the Doer interface
+type doerFunc func(req *http.Request) (*http.Response, error)
+
+func (f doerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestWithDoer(t *testing.T) {
+	var paths []string
+	recorded := doerFunc(func(req *http.Request) (*http.Response, error) {
+		paths = append(paths, req.URL.Path)
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(cannedPriceResponse)),
+			Request:    req,
+		}, nil
+	})
+
+	client := NewClientWithOptions(WithBaseURL("http://odos.invalid"), WithDoer(recorded))
+	price, err := client.GetTokenPrice(chainId, DAI)
+	if err != nil {
+		t.Fatalf("GetTokenPrice() error = %v", err)
+	}
+	if price.Price != 1.0001 || len(paths) != 1 || !strings.HasPrefix(paths[0], "/pricing/token/1/") {
+		t.Errorf("GetTokenPrice() = %+v via %v", price, paths)
+	}
+
+	client = NewClientWithOptions(WithDoer(recorded), WithHTTPClient(&http.Client{}))
+	if client.sender() != client.httpClient {
+		t.Error("WithHTTPClient() after WithDoer() kept the Doer")
+	}
+}
@@ -0,0 +1,125 @@
+package odos
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/ThreeAndTwo/dex-swap-api-helper/internal/address"
+	"github.com/ThreeAndTwo/dex-swap-api-helper/units"
+)
+
+var (
+	// ErrNoRoute is returned when Odos finds no viable path for the quote
+	ErrNoRoute = errors.New("odos: no route found")
+	// ErrRateLimited is returned when Odos rejects the request with 429
+	ErrRateLimited = errors.New("odos: rate limited")
+	// ErrPathExpired is returned when assembling a path id that expired or is unknown
+	ErrPathExpired = errors.New("odos: path expired")
+	// ErrInvalidAddress is returned for malformed token or user addresses
+	ErrInvalidAddress = address.ErrInvalid
+	// ErrInvalidAmount is returned for amounts that aren't raw integer amounts
+	ErrInvalidAmount = units.ErrInvalidAmount
+	// ErrInvalidReferralCode is returned for referral codes outside the
+	// range Odos accepts
+	ErrInvalidReferralCode = errors.New("odos: invalid referral code")
+	// ErrInvalidProportions is returned when the output proportions of a
+	// quote don't sum to 1
+	ErrInvalidProportions = errors.New("odos: invalid output proportions")
+	// ErrNoMarketPrice is returned when Odos has no market price for a token
+	ErrNoMarketPrice = errors.New("odos: no market price")
+	// ErrNoPrice is returned by GetTokenPrice for a token Odos has no price
+	// for, instead of a zero price. It is the same error as ErrNoMarketPrice.
+	ErrNoPrice = ErrNoMarketPrice
+	// ErrStaleQuote is returned when a quote is older than the configured
+	// number of blocks when it is assembled
+	ErrStaleQuote = errors.New("odos: stale quote")
+	// ErrUnknownToken is returned when a token isn't in the Odos token list
+	ErrUnknownToken = errors.New("odos: unknown token")
+	// ErrUnknownSource is returned when a liquidity source id isn't
+	// available on the chain
+	ErrUnknownSource = errors.New("odos: unknown liquidity source")
+)
+
+// Odos error code for a quote without any viable path
+const codeNoViablePath = 2000
+
+// APIError represents an error response returned by the Odos API
+type APIError struct {
+	StatusCode int
+	Code       int
+	Message    string
+	RequestID  string // traceId reported by Odos
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("unexpected status code: %d, code: %d, message: %s, request id: %s",
+		e.StatusCode, e.Code, e.Message, e.RequestID)
+}
+
+// Is makes APIError match ErrNoRoute, ErrRateLimited, ErrPathExpired and
+// ErrNoPrice with errors.Is
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrNoRoute:
+		return e.Code == codeNoViablePath
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrPathExpired:
+		return e.isPathExpired()
+	case ErrNoPrice:
+		return e.isNoPrice()
+	}
+	return false
+}
+
+// isPathExpired reports whether the error rejects the path id of an
+// assemble request, Odos drops path ids shortly after quoting
+func (e *APIError) isPathExpired() bool {
+	if e.StatusCode < 400 || e.StatusCode >= 500 {
+		return false
+	}
+
+	msg := strings.ToLower(e.Message)
+	return strings.Contains(msg, "path") &&
+		(strings.Contains(msg, "expired") || strings.Contains(msg, "not found") || strings.Contains(msg, "invalid"))
+}
+
+// isNoPrice reports whether the error rejects a price request because Odos
+// doesn't price the token
+func (e *APIError) isNoPrice() bool {
+	if e.StatusCode < 400 || e.StatusCode >= 500 {
+		return false
+	}
+
+	msg := strings.ToLower(e.Message)
+	return strings.Contains(msg, "price") &&
+		(strings.Contains(msg, "not found") || strings.Contains(msg, "no price") || strings.Contains(msg, "unavailable") ||
+			strings.Contains(msg, "unable"))
+}
+
+// newAPIError builds an APIError from a failed response, falling back to the
+// raw body as message when it isn't the usual JSON error envelope
+func newAPIError(statusCode int, body []byte) *APIError {
+	apiErr := &APIError{StatusCode: statusCode}
+
+	var errResp struct {
+		Detail    string `json:"detail"`
+		TraceId   string `json:"traceId"`
+		ErrorCode int    `json:"errorCode"`
+	}
+	if err := json.Unmarshal(body, &errResp); err != nil {
+		apiErr.Message = string(body)
+		return apiErr
+	}
+
+	apiErr.Code = errResp.ErrorCode
+	apiErr.Message = errResp.Detail
+	apiErr.RequestID = errResp.TraceId
+	if apiErr.Message == "" {
+		apiErr.Message = string(body)
+	}
+	return apiErr
+}
@@ -0,0 +1,74 @@
+package odos
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestQuote_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		body       string
+		target     error
+	}{
+		{
+			name:       "no viable path",
+			statusCode: http.StatusBadRequest,
+			body:       `{"detail":"No viable path","traceId":"abc","errorCode":2000}`,
+			target:     ErrNoRoute,
+		},
+		{
+			name:       "rate limited",
+			statusCode: http.StatusTooManyRequests,
+			body:       `too many requests`,
+			target:     ErrRateLimited,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.statusCode)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			client := NewClient(server.URL)
+			_, err := client.Quote(&QuoteRequest{ChainId: 1})
+			if !errors.Is(err, tt.target) {
+				t.Fatalf("Quote() error = %v, want %v", err, tt.target)
+			}
+
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("Quote() error = %v, want *APIError", err)
+			}
+			if apiErr.StatusCode != tt.statusCode {
+				t.Errorf("APIError.StatusCode = %d, want %d", apiErr.StatusCode, tt.statusCode)
+			}
+		})
+	}
+}
+
+func TestQuoteWithMeta(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "41")
+		w.Write([]byte(`{"pathId":"abc"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	got, header, err := client.QuoteWithMeta(&QuoteRequest{ChainId: 1})
+	if err != nil {
+		t.Fatalf("QuoteWithMeta() error = %v", err)
+	}
+	if got.PathId != "abc" {
+		t.Errorf("QuoteWithMeta() pathId = %s", got.PathId)
+	}
+	if header.Get("X-RateLimit-Remaining") != "41" {
+		t.Errorf("X-RateLimit-Remaining = %q, want 41", header.Get("X-RateLimit-Remaining"))
+	}
+}
@@ -0,0 +1,35 @@
+// Package ethtx converts Odos assembled transactions to go-ethereum
+// transactions. It is a separate module so that only the users of this
+// package depend on go-ethereum. It requires a published version of the
+// root module, to build it against a local checkout create an uncommitted
+// workspace at the repository root with go work init . ./odos/ethtx.
+package ethtx
+
+import (
+	"github.com/ThreeAndTwo/dex-swap-api-helper/odos"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// ToEthTx converts the transaction returned by Assemble to an unsigned
+// EIP-1559 go-ethereum transaction, decoding the hex data and decimal value.
+// A legacy transaction without 1559 fee fields pays GasPrice as both fee cap
+// and tip cap.
+func ToEthTx(t *odos.Transaction) (*types.Transaction, error) {
+	tx, err := t.DynamicFeeTx()
+	if err != nil {
+		return nil, err
+	}
+
+	to := common.Address(tx.To)
+	return types.NewTx(&types.DynamicFeeTx{
+		ChainID:   tx.ChainID,
+		Nonce:     tx.Nonce,
+		GasTipCap: tx.GasTipCap,
+		GasFeeCap: tx.GasFeeCap,
+		Gas:       tx.Gas,
+		To:        &to,
+		Value:     tx.Value,
+		Data:      tx.Data,
+	}), nil
+}
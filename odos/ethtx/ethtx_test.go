@@ -0,0 +1,44 @@
+package ethtx
+
+import (
+	"testing"
+
+	"github.com/ThreeAndTwo/dex-swap-api-helper/odos"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestToEthTx(t *testing.T) {
+	tx, err := ToEthTx(&odos.Transaction{
+		Gas:                  235623,
+		Value:                "1000000000000000000",
+		To:                   "0xcf5540fffcdc3d510b18bfca6d2b9987b0772559",
+		Data:                 "0x83bd37f9",
+		Nonce:                7,
+		ChainId:              1,
+		MaxFeePerGas:         30000000000,
+		MaxPriorityFeePerGas: 1000000000,
+	})
+	if err != nil {
+		t.Fatalf("ToEthTx() error = %v", err)
+	}
+
+	if *tx.To() != common.HexToAddress("0xCf5540fFFCdC3d510B18bFcA6d2b9987b0772559") {
+		t.Errorf("To = %s", tx.To())
+	}
+	if tx.Value().String() != "1000000000000000000" || tx.Gas() != 235623 || tx.Nonce() != 7 {
+		t.Errorf("value %s, gas %d, nonce %d", tx.Value(), tx.Gas(), tx.Nonce())
+	}
+	if tx.GasFeeCap().Int64() != 30000000000 || tx.GasTipCap().Int64() != 1000000000 || tx.ChainId().Int64() != 1 {
+		t.Errorf("fee cap %s, tip cap %s, chain %s", tx.GasFeeCap(), tx.GasTipCap(), tx.ChainId())
+	}
+	if common.Bytes2Hex(tx.Data()) != "83bd37f9" {
+		t.Errorf("Data = %x", tx.Data())
+	}
+
+	if tx, err := ToEthTx(&odos.Transaction{To: "0xcf5540fffcdc3d510b18bfca6d2b9987b0772559", Value: "0x10"}); err != nil || tx.Value().Int64() != 16 {
+		t.Errorf("ToEthTx() of a hex value = %v, %v", tx, err)
+	}
+	if _, err := ToEthTx(&odos.Transaction{To: "0xcf5540fffcdc3d510b18bfca6d2b9987b0772559", Value: "1.5"}); err == nil {
+		t.Error("ToEthTx() accepted a fractional value")
+	}
+}
@@ -0,0 +1,30 @@
+module github.com/ThreeAndTwo/dex-swap-api-helper/odos/ethtx
+
+go 1.22.2
+
+require (
+	github.com/ThreeAndTwo/dex-swap-api-helper v0.0.0-20261016021016-c461d223226e
+	github.com/ethereum/go-ethereum v1.14.12
+)
+
+require (
+	github.com/andybalholm/brotli v1.1.1 // indirect
+	github.com/bits-and-blooms/bitset v1.13.0 // indirect
+	github.com/consensys/bavard v0.1.13 // indirect
+	github.com/consensys/gnark-crypto v0.12.1 // indirect
+	github.com/crate-crypto/go-ipa v0.0.0-20240223125850-b1e8a79f509c // indirect
+	github.com/crate-crypto/go-kzg-4844 v1.0.0 // indirect
+	github.com/decred/dcrd/dcrec/secp256k1/v4 v4.0.1 // indirect
+	github.com/ethereum/c-kzg-4844 v1.0.0 // indirect
+	github.com/ethereum/go-verkle v0.1.1-0.20240829091221-dffa7562dbe9 // indirect
+	github.com/holiman/uint256 v1.3.1 // indirect
+	github.com/mattn/go-colorable v0.1.13 // indirect
+	github.com/mattn/go-isatty v0.0.20 // indirect
+	github.com/mmcloughlin/addchain v0.4.0 // indirect
+	github.com/rs/zerolog v1.33.0 // indirect
+	github.com/supranational/blst v0.3.13 // indirect
+	golang.org/x/crypto v0.31.0 // indirect
+	golang.org/x/sync v0.7.0 // indirect
+	golang.org/x/sys v0.28.0 // indirect
+	rsc.io/tmplfunc v0.0.3 // indirect
+)
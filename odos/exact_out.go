@@ -0,0 +1,144 @@
+package odos
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ThreeAndTwo/dex-swap-api-helper/chains"
+)
+
+// DefaultExactOutIterations bounds the forward quotes of QuoteExactOut when
+// the request sets no limit
+const DefaultExactOutIterations = 4
+
+// ErrExactOutNotReached is returned by QuoteExactOut when no input amount
+// reaching the requested output was found within the iteration limit
+var ErrExactOutNotReached = errors.New("odos: exact output not reached")
+
+// ExactOutRequest describes a swap receiving exactly AmountOut of TokenOut
+// for as little TokenIn as possible
+type ExactOutRequest struct {
+	ChainId  chains.ChainID
+	TokenIn  string
+	TokenOut string
+	// AmountOut is the raw integer amount of TokenOut to receive
+	AmountOut            string
+	UserAddr             string
+	SlippageLimitPercent float64
+	// MaxIterations bounds the forward quotes, 0 uses DefaultExactOutIterations
+	MaxIterations int
+}
+
+// NewExactOutRequest creates an exact output request with the slippage
+// default of NewQuoteRequest
+func NewExactOutRequest(chainID chains.ChainID, tokenIn, tokenOut, amountOut string) *ExactOutRequest {
+	return &ExactOutRequest{
+		ChainId:              chainID,
+		TokenIn:              tokenIn,
+		TokenOut:             tokenOut,
+		AmountOut:            amountOut,
+		SlippageLimitPercent: DefaultSlippageLimitPercent,
+	}
+}
+
+// WithUserAddr sets the address that will execute the swap, required to
+// assemble the resulting quote
+func (r *ExactOutRequest) WithUserAddr(userAddr string) *ExactOutRequest {
+	r.UserAddr = userAddr
+	return r
+}
+
+// ExactOutQuote is the result of QuoteExactOut
+type ExactOutQuote struct {
+	// AmountIn is the raw integer amount of TokenIn to sell
+	AmountIn string
+	// Quote is the exact input quote of AmountIn, its output is at least the
+	// requested amount. Its PathId can be assembled as usual.
+	Quote *QuoteResponse
+	// Iterations is the number of forward quotes it took
+	Iterations int
+}
+
+// quoteRequest returns the exact input request selling amountIn
+func (r *ExactOutRequest) quoteRequest(amountIn string) *QuoteRequest {
+	return NewQuoteRequest(r.ChainId, nil, nil).
+		AddInput(r.TokenIn, amountIn).
+		SetSingleOutput(r.TokenOut).
+		WithUserAddr(r.UserAddr).
+		WithSlippageLimitPercent(r.SlippageLimitPercent)
+}
+
+// QuoteExactOut finds the input amount receiving at least req.AmountOut.
+// Odos only quotes exact input swaps: the search starts from the reverse
+// quote, selling AmountOut of TokenOut, then rescales the input by the
+// shortfall of each forward quote until the output is reached.
+func (c *OdosClient) QuoteExactOut(req *ExactOutRequest) (*ExactOutQuote, error) {
+	want, ok := new(big.Int).SetString(req.AmountOut, 10)
+	if !ok || want.Sign() <= 0 {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidAmount, req.AmountOut)
+	}
+
+	maxIterations := req.MaxIterations
+	if maxIterations <= 0 {
+		maxIterations = DefaultExactOutIterations
+	}
+
+	reverse, err := c.QuotePrice(NewQuoteRequest(req.ChainId, nil, nil).
+		AddInput(req.TokenOut, req.AmountOut).
+		SetSingleOutput(req.TokenIn))
+	if err != nil {
+		return nil, fmt.Errorf("failed to quote reverse swap: %w", err)
+	}
+	amountIn, err := firstAmount(reverse.OutAmountsInt())
+	if err != nil {
+		return nil, err
+	}
+
+	var got *big.Int
+	for i := 1; i <= maxIterations; i++ {
+		if amountIn.Sign() <= 0 {
+			amountIn = big.NewInt(1)
+		}
+
+		quote, err := c.Quote(req.quoteRequest(amountIn.String()))
+		if err != nil {
+			return nil, err
+		}
+		if got, err = firstAmount(quote.OutAmountsInt()); err != nil {
+			return nil, err
+		}
+		if got.Cmp(want) >= 0 {
+			return &ExactOutQuote{AmountIn: amountIn.String(), Quote: quote, Iterations: i}, nil
+		}
+
+		amountIn = nextExactOutInput(amountIn, got, want)
+	}
+
+	return nil, fmt.Errorf("%w: %d iterations, last output %s of %s", ErrExactOutNotReached, maxIterations, got, want)
+}
+
+// nextExactOutInput scales amountIn by want/got, rounded up with a 1bp
+// margin so that a slightly non-linear price still closes the gap
+func nextExactOutInput(amountIn, got, want *big.Int) *big.Int {
+	if got.Sign() <= 0 {
+		return new(big.Int).Mul(amountIn, big.NewInt(2))
+	}
+
+	next := new(big.Int).Mul(amountIn, want)
+	next.Add(next, new(big.Int).Sub(got, big.NewInt(1)))
+	next.Quo(next, got)
+	next.Add(next, new(big.Int).Quo(next, big.NewInt(10000)))
+	return next.Add(next, big.NewInt(1))
+}
+
+// firstAmount returns the first of amounts
+func firstAmount(amounts []*big.Int, err error) (*big.Int, error) {
+	if err != nil {
+		return nil, err
+	}
+	if len(amounts) == 0 {
+		return nil, fmt.Errorf("%w: no amount in quote", ErrInvalidAmount)
+	}
+	return amounts[0], nil
+}
@@ -0,0 +1,83 @@
+package odos
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"testing"
+)
+
+// linearQuote quotes every swap at rate percent of the input amount
+func linearQuote(t *testing.T, rate int64, quotes *[]QuoteRequest) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req QuoteRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decode request: %v", err)
+		}
+		*quotes = append(*quotes, req)
+
+		in, _ := new(big.Int).SetString(req.InputTokens[0].Amount, 10)
+		out := new(big.Int).Quo(new(big.Int).Mul(in, big.NewInt(rate)), big.NewInt(100))
+		json.NewEncoder(w).Encode(QuoteResponse{
+			InAmounts:  []string{in.String()},
+			OutAmounts: []string{out.String()},
+			PathId:     "path-" + in.String(),
+		})
+	}
+}
+
+func TestQuoteExactOut(t *testing.T) {
+	const user = "0x163A5EC5e9C32238d075E2D829fE9fA87451e3b7"
+	var quotes []QuoteRequest
+	server, client := NewTestServer(TestHandlers{Quote: linearQuote(t, 99, &quotes)})
+	defer server.Close()
+
+	req := NewExactOutRequest(1, sUSDe, DAI, "1000000000000000000000").WithUserAddr(user)
+	got, err := client.QuoteExactOut(req)
+	if err != nil {
+		t.Fatalf("QuoteExactOut() error = %v", err)
+	}
+
+	if quotes[0].InputTokens[0].TokenAddress != DAI || quotes[0].UserAddr != "" {
+		t.Errorf("first quote = %+v, want the reverse price quote", quotes[0])
+	}
+	last := quotes[len(quotes)-1]
+	if last.InputTokens[0].TokenAddress != sUSDe || last.UserAddr != user {
+		t.Errorf("last quote = %+v, want the forward quote for the user", last)
+	}
+
+	out, _ := new(big.Int).SetString(got.Quote.OutAmounts[0], 10)
+	want, _ := new(big.Int).SetString(req.AmountOut, 10)
+	if out.Cmp(want) < 0 {
+		t.Errorf("output %s is below the requested %s", out, want)
+	}
+	// 1000 DAI at 0.99 costs 1010.1 sUSDe, allow the 1bp search margin
+	limit, _ := new(big.Int).SetString("1010305000000000000000", 10)
+	in, _ := new(big.Int).SetString(got.AmountIn, 10)
+	if in.Cmp(limit) > 0 {
+		t.Errorf("AmountIn = %s, overshoots %s", in, limit)
+	}
+	if got.Iterations != 2 || got.Quote.PathId != "path-"+got.AmountIn {
+		t.Errorf("QuoteExactOut() = %+v", got)
+	}
+}
+
+func TestQuoteExactOut_NotReached(t *testing.T) {
+	var quotes []QuoteRequest
+	server, client := NewTestServer(TestHandlers{Quote: linearQuote(t, 0, &quotes)})
+	defer server.Close()
+
+	req := NewExactOutRequest(1, sUSDe, DAI, "1000")
+	req.MaxIterations = 3
+	if _, err := client.QuoteExactOut(req); !errors.Is(err, ErrExactOutNotReached) {
+		t.Errorf("QuoteExactOut() error = %v, want %v", err, ErrExactOutNotReached)
+	}
+	if len(quotes) != 4 {
+		t.Errorf("sent %d quotes, want the reverse quote and 3 forward quotes", len(quotes))
+	}
+
+	if _, err := client.QuoteExactOut(NewExactOutRequest(1, sUSDe, DAI, "1.5")); !errors.Is(err, ErrInvalidAmount) {
+		t.Errorf("QuoteExactOut() error = %v, want %v", err, ErrInvalidAmount)
+	}
+}
@@ -0,0 +1,48 @@
+package odos
+
+import (
+	"fmt"
+	"time"
+)
+
+// IsStale reports whether the quote is more than maxAge blocks behind
+// currentBlock
+func (q *QuoteResponse) IsStale(currentBlock, maxAge int64) bool {
+	return currentBlock-q.BlockNumber > maxAge
+}
+
+// IsStale reports whether the assembled transaction is more than maxAge
+// blocks behind currentBlock
+func (a *AssembleResponse) IsStale(currentBlock, maxAge int64) bool {
+	return currentBlock-a.BlockNumber > maxAge
+}
+
+// WithMaxQuoteAge makes AssembleQuote and Swap reject quotes that are more
+// than blocks behind the block the transaction is assembled at, with
+// ErrStaleQuote. 0 disables the check.
+func WithMaxQuoteAge(blocks int64) Option {
+	return func(c *OdosClient) {
+		c.maxAge = blocks
+	}
+}
+
+// AssembleQuote assembles the path of quote for userAddr. It fails with
+// ErrPathExpired without calling Odos once the path id expired, see
+// PathIdExpiresAt. With WithMaxQuoteAge it fails with ErrStaleQuote when the
+// quote is older than the configured number of blocks at the assembled block.
+func (c *OdosClient) AssembleQuote(userAddr string, quote *QuoteResponse, isSimulate bool) (*AssembleResponse, error) {
+	if quote.PathIdRemaining() == 0 {
+		return nil, fmt.Errorf("%w: path id %s expired at %s", ErrPathExpired, quote.PathId, quote.expiresAt.Format(time.RFC3339))
+	}
+
+	assembleResp, err := c.Assemble(userAddr, quote.PathId, isSimulate)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.maxAge > 0 && quote.IsStale(assembleResp.BlockNumber, c.maxAge) {
+		return nil, fmt.Errorf("%w: quoted at block %d, assembled at block %d, max age %d blocks",
+			ErrStaleQuote, quote.BlockNumber, assembleResp.BlockNumber, c.maxAge)
+	}
+	return assembleResp, nil
+}
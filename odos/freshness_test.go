@@ -0,0 +1,44 @@
+package odos
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestQuoteResponse_IsStale(t *testing.T) {
+	quote := &QuoteResponse{BlockNumber: 100}
+	if quote.IsStale(105, 5) {
+		t.Errorf("IsStale(105, 5) = true at the max age")
+	}
+	if !quote.IsStale(130, 5) {
+		t.Errorf("IsStale(130, 5) = false")
+	}
+
+	assembled := &AssembleResponse{BlockNumber: 100}
+	if !assembled.IsStale(102, 1) {
+		t.Errorf("AssembleResponse.IsStale(102, 1) = false")
+	}
+}
+
+func TestWithMaxQuoteAge(t *testing.T) {
+	assembleBlock := int64(21000002)
+	server, client := NewTestServer(TestHandlers{
+		Assemble: func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, strings.Replace(cannedAssembleResponse, `"blockNumber":21000000`, fmt.Sprintf(`"blockNumber":%d`, assembleBlock), 1))
+		},
+	}, WithMaxQuoteAge(5))
+	defer server.Close()
+
+	req := NewQuoteRequest(1, nil, nil).AddInput(DAI, "1000000000000000000").SetSingleOutput(sUSDe).WithUserAddr(testUserAddr)
+	if _, err := client.Swap(req, false); err != nil {
+		t.Fatalf("Swap() error = %v", err)
+	}
+
+	assembleBlock = 21000030
+	if _, err := client.Swap(req, false); !errors.Is(err, ErrStaleQuote) {
+		t.Fatalf("Swap() error = %v, want ErrStaleQuote", err)
+	}
+}
@@ -0,0 +1,137 @@
+package odos
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/ThreeAndTwo/dex-swap-api-helper/chains"
+)
+
+// GasOracle returns the current gas price in gwei for a chain
+type GasOracle func(chainID chains.ChainID) (float64, error)
+
+// GasPriceLevel represents one fee level of the gas price endpoint
+type GasPriceLevel struct {
+	Fee        float64 `json:"fee"`
+	SizeFactor float64 `json:"sizeFactor"`
+}
+
+// GasPriceResponse represents the response from gas price endpoint
+type GasPriceResponse struct {
+	ChainId chains.ChainID  `json:"chainId"`
+	BaseFee float64         `json:"baseFee"`
+	Prices  []GasPriceLevel `json:"prices"`
+}
+
+// gasPriceCache holds the last gas price fetched per chain
+type gasPriceCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[chains.ChainID]cachedGasPrice
+}
+
+type cachedGasPrice struct {
+	price     float64
+	fetchedAt time.Time
+}
+
+// GetGasPrice fetches the gas price Odos uses for a chain
+// /gas/price/{chainId}
+func (c *OdosClient) GetGasPrice(chainID chains.ChainID) (*GasPriceResponse, error) {
+	url := fmt.Sprintf("%s/gas/price/%d", c.baseURL, chainID)
+
+	request, err := c.newRequest("GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.do(EndpointGasPrice, request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get gas price: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("status code %d, failed to read error response: %w", resp.StatusCode, err)
+		}
+		return nil, newAPIError(resp.StatusCode, body)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	var gasResp GasPriceResponse
+	if err := c.decodeStrict(resp.StatusCode, body, &gasResp); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &gasResp, nil
+}
+
+// WithAutoGasPrice fills QuoteRequest.GasPrice when it is left at 0, using
+// the Odos gas price endpoint unless a GasOracle is set. Prices are cached
+// per chain for ttl.
+func (c *OdosClient) WithAutoGasPrice(ttl time.Duration) *OdosClient {
+	c.gasPrices = &gasPriceCache{
+		ttl:     ttl,
+		entries: make(map[chains.ChainID]cachedGasPrice),
+	}
+	return c
+}
+
+// WithGasOracle sets the source used by WithAutoGasPrice, enabling auto gas
+// price with no caching if it isn't enabled yet
+func (c *OdosClient) WithGasOracle(oracle GasOracle) *OdosClient {
+	c.gasOracle = oracle
+	if c.gasPrices == nil {
+		c.WithAutoGasPrice(0)
+	}
+	return c
+}
+
+// gasPrice returns the current gas price in gwei for chainID, served from
+// the cache while it is fresh. The lock isn't held while fetching, so a slow
+// fetch doesn't hold up the quotes served from the cache.
+func (c *OdosClient) gasPrice(chainID chains.ChainID) (float64, error) {
+	cache := c.gasPrices
+
+	cache.mu.Lock()
+	entry, ok := cache.entries[chainID]
+	cache.mu.Unlock()
+	if ok && time.Since(entry.fetchedAt) < cache.ttl {
+		return entry.price, nil
+	}
+
+	price, err := c.fetchGasPrice(chainID)
+	if err != nil {
+		return 0, err
+	}
+
+	cache.mu.Lock()
+	cache.entries[chainID] = cachedGasPrice{price: price, fetchedAt: time.Now()}
+	cache.mu.Unlock()
+	return price, nil
+}
+
+func (c *OdosClient) fetchGasPrice(chainID chains.ChainID) (float64, error) {
+	if c.gasOracle != nil {
+		return c.gasOracle(chainID)
+	}
+
+	gasResp, err := c.GetGasPrice(chainID)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(gasResp.Prices) > 0 {
+		return gasResp.Prices[0].Fee, nil
+	}
+	return gasResp.BaseFee, nil
+}
@@ -0,0 +1,42 @@
+package odos
+
+import "math"
+
+// GasBuffer pads the gas estimate of assembled transactions, covering state
+// changes between estimation and execution that would make the exact
+// estimate run out of gas
+type GasBuffer struct {
+	Percent float64 // added percentage of the estimate, 20 for 20%
+	Flat    int64   // added gas units
+}
+
+// WithGasBuffer sets the gas limit of assembled transactions to their
+// GasEstimate padded by buffer, when that is above the gas Odos set
+func WithGasBuffer(buffer GasBuffer) Option {
+	return func(c *OdosClient) {
+		c.gasBuffer = &buffer
+	}
+}
+
+// GasLimitWithBuffer returns GasEstimate increased by pct percent, rounded
+// up, e.g. 20 for a 20% buffer. A negative pct is treated as 0.
+func (a *AssembleResponse) GasLimitWithBuffer(pct float64) int64 {
+	return GasBuffer{Percent: pct}.apply(a.GasEstimate)
+}
+
+// apply returns gas padded by the buffer
+func (b GasBuffer) apply(gas int64) int64 {
+	pct := math.Max(b.Percent, 0)
+	return int64(math.Ceil(float64(gas)*(1+pct/100))) + max(b.Flat, 0)
+}
+
+// applyGasBuffer raises the transaction gas limit to the buffered estimate
+// when WithGasBuffer is set
+func (c *OdosClient) applyGasBuffer(assembleResp *AssembleResponse) {
+	if c.gasBuffer == nil || assembleResp.GasEstimate <= 0 {
+		return
+	}
+	if limit := c.gasBuffer.apply(assembleResp.GasEstimate); limit > assembleResp.Transaction.Gas {
+		assembleResp.Transaction.Gas = limit
+	}
+}
@@ -0,0 +1,47 @@
+package odos
+
+import "testing"
+
+func TestAssembleResponse_GasLimitWithBuffer(t *testing.T) {
+	resp := AssembleResponse{GasEstimate: 235623}
+	tests := []struct {
+		pct  float64
+		want int64
+	}{
+		{0, 235623},
+		{20, 282748},
+		{-5, 235623},
+	}
+	for _, tt := range tests {
+		if got := resp.GasLimitWithBuffer(tt.pct); got != tt.want {
+			t.Errorf("GasLimitWithBuffer(%v) = %d, want %d", tt.pct, got, tt.want)
+		}
+	}
+}
+
+func TestWithGasBuffer(t *testing.T) {
+	tests := []struct {
+		name   string
+		buffer GasBuffer
+		want   int64
+	}{
+		{name: "below odos gas", buffer: GasBuffer{Percent: 20}, want: 353434},
+		{name: "percent", buffer: GasBuffer{Percent: 60}, want: 376997},
+		{name: "percent and flat", buffer: GasBuffer{Percent: 50, Flat: 50000}, want: 403435},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, client := NewTestServer(TestHandlers{}, WithGasBuffer(tt.buffer))
+			defer server.Close()
+
+			assembleResp, err := client.Assemble(testUserAddr, "9c2294c5e076d888e149c764f832738b", false)
+			if err != nil {
+				t.Fatalf("Assemble() error = %v", err)
+			}
+			if assembleResp.Transaction.Gas != tt.want {
+				t.Errorf("Transaction.Gas = %d, want %d", assembleResp.Transaction.Gas, tt.want)
+			}
+		})
+	}
+}
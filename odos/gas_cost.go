@@ -0,0 +1,55 @@
+package odos
+
+import (
+	"math"
+	"math/big"
+
+	"github.com/ThreeAndTwo/dex-swap-api-helper/internal/codec"
+)
+
+// TotalGas returns the total gas units of the quoted path, the execution gas
+// plus the calldata gas Odos reports for the L1 data fee on rollups. Both are
+// priced at GweiPerGas, DataGasEstimate being 0 on L1 chains.
+func (q *QuoteResponse) TotalGas() int64 {
+	return max(q.GasEstimate, 0) + max(q.DataGasEstimate, 0)
+}
+
+// TotalGasCostWei returns the total gas cost of the quoted path in wei,
+// covering execution and L1 calldata gas
+func (q *QuoteResponse) TotalGasCostWei() *big.Int {
+	weiPerGas := big.NewInt(int64(math.Round(max(q.GweiPerGas, 0) * 1e9)))
+	return weiPerGas.Mul(weiPerGas, big.NewInt(q.TotalGas()))
+}
+
+// TotalGasCostUSD returns the total gas cost of the quoted path in USD.
+// GasEstimateValue only values the execution gas, so it is scaled up by the
+// share of calldata gas in the total.
+func (q *QuoteResponse) TotalGasCostUSD() float64 {
+	if q.GasEstimate <= 0 {
+		return q.GasEstimateValue
+	}
+	return q.GasEstimateValue * float64(q.TotalGas()) / float64(q.GasEstimate)
+}
+
+// UnmarshalJSON decodes the quote, rounding the gas estimates Odos sends as
+// floats to whole gas units like those of AssembleResponse
+func (q *QuoteResponse) UnmarshalJSON(data []byte) error {
+	return q.unmarshalWith(codec.Standard, data)
+}
+
+func (q *QuoteResponse) unmarshalWith(jsonCodec Codec, data []byte) error {
+	type fields QuoteResponse
+	var quote struct {
+		fields
+		GasEstimate     float64 `json:"gasEstimate"`
+		DataGasEstimate float64 `json:"dataGasEstimate"`
+	}
+	if err := jsonCodec.Unmarshal(data, &quote); err != nil {
+		return err
+	}
+
+	*q = QuoteResponse(quote.fields)
+	q.GasEstimate = int64(math.Round(quote.GasEstimate))
+	q.DataGasEstimate = int64(math.Round(quote.DataGasEstimate))
+	return nil
+}
@@ -0,0 +1,67 @@
+package odos
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+func TestQuoteResponse_TotalGasCost(t *testing.T) {
+	tests := []struct {
+		name    string
+		quote   QuoteResponse
+		wantWei string
+		wantUSD float64
+		wantGas int64
+	}{
+		{
+			name:    "l1",
+			quote:   QuoteResponse{GasEstimate: 235623, GweiPerGas: 6.27, GasEstimateValue: 3.78},
+			wantWei: "1477356210000000",
+			wantUSD: 3.78,
+			wantGas: 235623,
+		},
+		{
+			name:    "l2 with calldata",
+			quote:   QuoteResponse{GasEstimate: 300000, DataGasEstimate: 100000, GweiPerGas: 0.01, GasEstimateValue: 0.3},
+			wantWei: "4000000000000",
+			wantUSD: 0.4,
+			wantGas: 400000,
+		},
+		{
+			name:    "no gas estimate",
+			quote:   QuoteResponse{GasEstimateValue: 1.5},
+			wantWei: "0",
+			wantUSD: 1.5,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.quote.TotalGas(); got != tt.wantGas {
+				t.Errorf("TotalGas() = %d, want %d", got, tt.wantGas)
+			}
+			if got := tt.quote.TotalGasCostWei().String(); got != tt.wantWei {
+				t.Errorf("TotalGasCostWei() = %s, want %s", got, tt.wantWei)
+			}
+			if got := tt.quote.TotalGasCostUSD(); math.Abs(got-tt.wantUSD) > 1e-9 {
+				t.Errorf("TotalGasCostUSD() = %v, want %v", got, tt.wantUSD)
+			}
+		})
+	}
+}
+
+func TestQuoteResponse_UnmarshalGasEstimate(t *testing.T) {
+	var quote QuoteResponse
+	data := `{"gasEstimate":235622.6,"dataGasEstimate":1200.2,"gasEstimateValue":3.78,"pathId":"abc","outAmounts":["1"]}`
+	if err := json.Unmarshal([]byte(data), &quote); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+
+	if quote.GasEstimate != 235623 || quote.DataGasEstimate != 1200 {
+		t.Errorf("gas estimates = %d, %d, want 235623, 1200", quote.GasEstimate, quote.DataGasEstimate)
+	}
+	if quote.GasEstimateValue != 3.78 || quote.PathId != "abc" || quote.OutAmounts[0] != "1" {
+		t.Errorf("QuoteResponse = %+v", quote)
+	}
+}
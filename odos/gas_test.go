@@ -0,0 +1,105 @@
+package odos
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ThreeAndTwo/dex-swap-api-helper/chains"
+)
+
+func TestQuote_AutoGasPrice(t *testing.T) {
+	var gasCalls int
+	var gotGasPrice float64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/gas/price/1":
+			gasCalls++
+			w.Write([]byte(`{"chainId":1,"baseFee":9.5,"prices":[{"fee":12.5,"sizeFactor":1}]}`))
+		case "/sor/quote/v2":
+			var req QuoteRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			gotGasPrice = req.GasPrice
+			w.Write([]byte(`{"pathId":"abc"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL).WithAutoGasPrice(time.Minute)
+	req := NewQuoteRequest(1, nil, nil)
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.Quote(req); err != nil {
+			t.Fatalf("Quote() error = %v", err)
+		}
+	}
+
+	if gotGasPrice != 12.5 {
+		t.Errorf("gasPrice = %v, want 12.5", gotGasPrice)
+	}
+	if gasCalls != 1 {
+		t.Errorf("gas price fetched %d times, want 1", gasCalls)
+	}
+	if req.GasPrice != 0 {
+		t.Errorf("Quote() modified the caller's request")
+	}
+}
+
+func TestQuote_GasOracle(t *testing.T) {
+	var gotGasPrice float64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req QuoteRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		gotGasPrice = req.GasPrice
+		w.Write([]byte(`{"pathId":"abc"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL).WithGasOracle(func(chainID chains.ChainID) (float64, error) {
+		return 3.25, nil
+	})
+
+	if _, err := client.Quote(NewQuoteRequest(1, nil, nil)); err != nil {
+		t.Fatalf("Quote() error = %v", err)
+	}
+	if gotGasPrice != 3.25 {
+		t.Errorf("gasPrice = %v, want 3.25", gotGasPrice)
+	}
+}
+
+func TestGasPrice_SlowFetch(t *testing.T) {
+	release := make(chan struct{})
+	fetching := make(chan struct{})
+	client := NewClient("").WithAutoGasPrice(time.Minute).WithGasOracle(func(chainID chains.ChainID) (float64, error) {
+		if chainID == 1 {
+			close(fetching)
+			<-release
+		}
+		return float64(chainID), nil
+	})
+	if _, err := client.gasPrice(10); err != nil {
+		t.Fatalf("gasPrice(10) error = %v", err)
+	}
+
+	go client.gasPrice(1)
+	<-fetching
+	defer close(release)
+
+	done := make(chan float64)
+	go func() {
+		price, _ := client.gasPrice(10)
+		done <- price
+	}()
+	select {
+	case price := <-done:
+		if price != 10 {
+			t.Errorf("gasPrice(10) = %v, want the cached 10", price)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("cached gasPrice(10) waited for the fetch of chain 1")
+	}
+}
@@ -0,0 +1,25 @@
+//go:build dexinsecure
+
+package odos
+
+import "crypto/tls"
+
+// WithInsecureTLS disables TLS certificate verification, e.g. to inspect the
+// traffic through a local MITM proxy such as mitmproxy during development.
+// It only exists in builds with the dexinsecure tag (go build -tags
+// dexinsecure) so it can't reach production binaries. Without the tag, pass
+// an HTTP client with a custom transport to WithHTTPClient instead. The
+// transport of the HTTP client is replaced by a configured copy, with a
+// connection pool of its own.
+func WithInsecureTLS() Option {
+	return func(c *OdosClient) {
+		transport := c.ownTransport()
+
+		if transport.TLSClientConfig == nil {
+			transport.TLSClientConfig = &tls.Config{}
+		}
+		transport.TLSClientConfig.InsecureSkipVerify = true
+
+		c.logger.Warn().Msg("TLS certificate verification is disabled")
+	}
+}
@@ -0,0 +1,113 @@
+package odos
+
+import "strings"
+
+// WithLikeAsset sets whether the quote only routes through pools of assets
+// alike to the input and output, such as stable pools for stablecoins or
+// LST pools for ETH and its staked forms. It gives better quotes and less
+// price impact between correlated assets, but for uncorrelated pairs it
+// leaves out the liquidity the best path needs, returning worse quotes or
+// none. Leave it unset to let WithLikeAssetDetection decide.
+func (r *QuoteRequest) WithLikeAsset(likeAsset bool) *QuoteRequest {
+	r.LikeAsset = likeAsset
+	r.likeAssetSet = true
+	return r
+}
+
+// WithLikeAssetDetection makes Quote set LikeAsset on requests that don't
+// set it with WithLikeAsset, enabling it only when every input and output
+// token tracks the same asset: they share the Odos asset id in the token
+// list, such as bridged versions of one stablecoin, or are wrapped or staked
+// forms of one asset, such as stETH and ETH. Tokens missing from the list
+// keep it disabled, the safe choice for uncorrelated pairs.
+func WithLikeAssetDetection() Option {
+	return func(c *OdosClient) {
+		c.likeAsset = true
+	}
+}
+
+// IsLikeAsset reports whether every input and output token of req tracks
+// the same asset, see WithLikeAssetDetection, fetching the token list of the
+// chain when needed
+func (c *OdosClient) IsLikeAsset(req *QuoteRequest) (bool, error) {
+	var asset string
+	check := func(tokenAddr string) (bool, error) {
+		info, err := c.GetTokenInfo(req.ChainId, tokenAddr)
+		if err != nil {
+			return false, err
+		}
+		base := likeAssetBase(info)
+		if base == "" || asset != "" && base != asset {
+			return false, nil
+		}
+		asset = base
+		return true, nil
+	}
+
+	if len(req.InputTokens) == 0 || len(req.OutputTokens) == 0 {
+		return false, nil
+	}
+	for _, in := range req.InputTokens {
+		if like, err := check(in.TokenAddress); !like || err != nil {
+			return false, err
+		}
+	}
+	for _, out := range req.OutputTokens {
+		if like, err := check(out.TokenAddress); !like || err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// _likeAssetBases maps the wrapped, staked and restaked forms of an asset,
+// by lowercase Odos asset id or symbol, to the asset they track
+var _likeAssetBases = map[string]string{
+	"eth":     "eth",
+	"weth":    "eth",
+	"steth":   "eth",
+	"wsteth":  "eth",
+	"reth":    "eth",
+	"cbeth":   "eth",
+	"frxeth":  "eth",
+	"sfrxeth": "eth",
+	"eeth":    "eth",
+	"weeth":   "eth",
+	"ezeth":   "eth",
+	"rseth":   "eth",
+	"meth":    "eth",
+	"btc":     "btc",
+	"wbtc":    "btc",
+	"cbbtc":   "btc",
+	"tbtc":    "btc",
+}
+
+// likeAssetBase returns the asset a token tracks, its lowercase Odos asset
+// id unless the asset id or symbol is a known form of another asset
+func likeAssetBase(info *TokenInfo) string {
+	for _, key := range []string{info.AssetID, info.Symbol} {
+		if base, ok := _likeAssetBases[strings.ToLower(key)]; ok {
+			return base
+		}
+	}
+	return strings.ToLower(info.AssetID)
+}
+
+// withDetectedLikeAsset returns req with LikeAsset detected when enabled by
+// WithLikeAssetDetection and not set by the caller
+func (c *OdosClient) withDetectedLikeAsset(req *QuoteRequest) *QuoteRequest {
+	if !c.likeAsset || req.likeAssetSet || req.LikeAsset {
+		return req
+	}
+
+	like, err := c.IsLikeAsset(req)
+	if err != nil {
+		c.logger.Debug().Err(err).Msg("like asset detection failed, leaving it disabled")
+		return req
+	}
+
+	detected := *req
+	detected.LikeAsset = like
+	detected.likeAssetSet = true
+	return &detected
+}
@@ -0,0 +1,55 @@
+package odos
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+const stETH = "0xae7ab96520DE3A18E5e111B5EaAb095312D7fE84"
+
+func TestWithLikeAssetDetection(t *testing.T) {
+	var sent []bool
+	server, client := NewTestServer(TestHandlers{
+		Tokens: func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"tokenMap":{"0x6B175474E89094C44Da98b954EedeAC495271d0F":{"symbol":"DAI","assetId":"usd"},` +
+				`"0x9D39A5DE30e57443BfF2A8307A4256c8797A3497":{"symbol":"sUSDe","assetId":"USD"},` +
+				`"0x7f39C581F595B53c5cb19bD0b3f8dA6c935E2Ca0":{"symbol":"wstETH","assetId":"eth"},` +
+				`"0xae7ab96520DE3A18E5e111B5EaAb095312D7fE84":{"symbol":"stETH","assetId":"lido-steth"},` +
+				`"0x0000000000000000000000000000000000000000":{"symbol":"ETH","assetId":"eth"}}}`))
+		},
+		Quote: func(w http.ResponseWriter, r *http.Request) {
+			var req QuoteRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			sent = append(sent, req.LikeAsset)
+			w.Write([]byte(cannedQuoteResponse))
+		},
+	}, WithLikeAssetDetection())
+	defer server.Close()
+
+	reqs := []*QuoteRequest{
+		NewQuoteRequest(1, nil, nil).AddInput(DAI, "1000").SetSingleOutput(sUSDe),
+		NewQuoteRequest(1, nil, nil).AddInput(DAI, "1000").SetSingleOutput(wstETH),
+		NewQuoteRequest(1, nil, nil).AddInput(DAI, "1000").SetSingleOutput(ezETH),
+		NewQuoteRequest(1, nil, nil).AddInput(DAI, "1000").SetSingleOutput(sUSDe).WithLikeAsset(false),
+		NewQuoteRequest(1, nil, nil).AddInput(DAI, "1000").SetSingleOutput(wstETH).WithLikeAsset(true),
+		NewQuoteRequest(1, nil, nil).AddInput(stETH, "1000").SetSingleOutput(NativeToken),
+		NewQuoteRequest(1, nil, nil).AddInput(stETH, "1000").SetSingleOutput(wstETH),
+		NewQuoteRequest(1, nil, nil).AddInput(stETH, "1000").SetSingleOutput(DAI),
+	}
+	for _, req := range reqs {
+		if _, err := client.Quote(req); err != nil {
+			t.Fatalf("Quote() error = %v", err)
+		}
+	}
+
+	want := []bool{true, false, false, false, true, true, true, false}
+	for i := range want {
+		if i >= len(sent) || sent[i] != want[i] {
+			t.Fatalf("likeAsset sent = %v, want %v", sent, want)
+		}
+	}
+	if reqs[0].LikeAsset {
+		t.Error("detection mutated the caller's request")
+	}
+}
@@ -0,0 +1,30 @@
+package odos
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+func TestLogging_StructuredAndRedacted(t *testing.T) {
+	var logs bytes.Buffer
+	server, client := NewTestServer(TestHandlers{}, WithLogger(zerolog.New(&logs).Level(zerolog.DebugLevel)))
+	defer server.Close()
+
+	req := NewQuoteRequest(1, nil, nil).AddInput(DAI, "1000000000000000000").SetSingleOutput(sUSDe).WithUserAddr(testUserAddr)
+	if _, err := client.Swap(req, false); err != nil {
+		t.Fatalf("Swap() error = %v", err)
+	}
+
+	out := logs.String()
+	for _, field := range []string{`"level":"debug"`, `"endpoint":"quote"`, `"endpoint":"assemble"`, `"status_code":200`} {
+		if !strings.Contains(out, field) {
+			t.Errorf("logs missing %s:\n%s", field, out)
+		}
+	}
+	if strings.Contains(strings.ToLower(out), strings.ToLower(testUserAddr)) {
+		t.Errorf("logs leak the user address:\n%s", out)
+	}
+}
@@ -0,0 +1,112 @@
+package odos
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ThreeAndTwo/dex-swap-api-helper/internal/compress"
+	"github.com/ThreeAndTwo/dex-swap-api-helper/internal/drain"
+)
+
+// Endpoint names reported to the Recorder
+const (
+	EndpointTokenPrice  = "token_price"
+	EndpointTokenPrices = "token_prices"
+	EndpointQuote       = "quote"
+	EndpointAssemble    = "assemble"
+	EndpointGasPrice    = "gas_price"
+	EndpointReferral    = "referral"
+	EndpointTokens      = "tokens"
+	EndpointPing        = "ping"
+	EndpointSources     = "liquidity_sources"
+)
+
+// Recorder receives an observation for every request of the client.
+// statusCode is 0 and err is set when no response was received, including
+// the calls failed before being sent, e.g. with ErrCircuitOpen.
+type Recorder interface {
+	ObserveRequest(endpoint string, dur time.Duration, statusCode int, err error)
+}
+
+// WithMetrics reports every request to recorder
+func WithMetrics(recorder Recorder) Option {
+	return func(c *OdosClient) {
+		c.recorder = recorder
+	}
+}
+
+// do sends req once the rate limiter lets it through, unless the circuit
+// breaker is open, retrying it while rate limited, reporting it to the
+// configured Recorder and RawCapture under endpoint. Bodies the transport
+// left compressed are decoded before being returned. The request counts as in
+// flight for Shutdown until the returned body is closed.
+func (c *OdosClient) do(endpoint string, req *http.Request) (resp *http.Response, err error) {
+	req, done, err := c.drain.Start(req)
+	if err != nil {
+		c.reject(endpoint, 0, err)
+		return nil, err
+	}
+	defer func() { drain.Track(resp, done) }()
+
+	if c.limiter != nil {
+		waitStart := time.Now()
+		if err := c.limiter.Wait(req.Context()); err != nil {
+			c.reject(endpoint, time.Since(waitStart), err)
+			return nil, err
+		}
+	}
+	if c.breaker != nil {
+		if err := c.breaker.Allow(); err != nil {
+			c.reject(endpoint, 0, err)
+			return nil, err
+		}
+	}
+
+	var reqBody []byte
+	if c.capture != nil {
+		reqBody = requestBody(req)
+	}
+
+	start := time.Now()
+	resp, err = c.send(req)
+
+	if c.breaker != nil {
+		c.breaker.Record(succeeded(resp, err))
+	}
+
+	c.stats.Observe(endpoint, time.Since(start), failed(resp, err))
+
+	if c.recorder != nil {
+		var statusCode int
+		if resp != nil {
+			statusCode = resp.StatusCode
+		}
+		c.recorder.ObserveRequest(endpoint, time.Since(start), statusCode, err)
+	}
+
+	if err == nil {
+		if derr := compress.Decode(resp); derr != nil {
+			resp, err = nil, fmt.Errorf("failed to decode response body: %w", derr)
+		}
+	}
+
+	if c.capture != nil {
+		if err != nil {
+			c.capture(endpoint, reqBody, nil)
+		} else {
+			captureResponse(c.capture, endpoint, reqBody, resp)
+		}
+	}
+
+	return resp, err
+}
+
+// reject reports a call failed before being sent, dur being the time it
+// waited for its turn
+func (c *OdosClient) reject(endpoint string, dur time.Duration, err error) {
+	c.stats.Reject(endpoint)
+	if c.recorder != nil {
+		c.recorder.ObserveRequest(endpoint, dur, 0, err)
+	}
+}
@@ -0,0 +1,81 @@
+package odos
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+type observation struct {
+	endpoint   string
+	statusCode int
+	err        error
+}
+
+type testRecorder struct {
+	mu           sync.Mutex
+	observations []observation
+}
+
+func (r *testRecorder) ObserveRequest(endpoint string, dur time.Duration, statusCode int, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.observations = append(r.observations, observation{endpoint, statusCode, err})
+}
+
+func TestWithMetrics(t *testing.T) {
+	recorder := &testRecorder{}
+	server, client := NewTestServer(TestHandlers{
+		Assemble: func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+		},
+	}, WithMetrics(recorder))
+	defer server.Close()
+
+	client.Swap(NewQuoteRequest(1, nil, nil).WithUserAddr(testUserAddr), false)
+
+	want := []observation{
+		{endpoint: EndpointQuote, statusCode: http.StatusOK},
+		{endpoint: EndpointAssemble, statusCode: http.StatusBadRequest},
+	}
+	if len(recorder.observations) != len(want) {
+		t.Fatalf("observations = %+v, want %+v", recorder.observations, want)
+	}
+	for i, o := range recorder.observations {
+		if o != want[i] {
+			t.Errorf("observation[%d] = %+v, want %+v", i, o, want[i])
+		}
+	}
+
+	server.Close()
+	client.GetTokenPrice(chainId, DAI)
+	last := recorder.observations[len(recorder.observations)-1]
+	if last.endpoint != EndpointTokenPrice || last.statusCode != 0 || last.err == nil {
+		t.Errorf("transport failure observation = %+v", last)
+	}
+}
+
+func TestWithMetrics_RateLimited(t *testing.T) {
+	recorder := &testRecorder{}
+	server, client := NewTestServer(TestHandlers{}, WithRateLimit(0.001, 1), WithMetrics(recorder))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := client.GetTokenPriceCtx(ctx, chainId, DAI); err != nil {
+		t.Fatalf("GetTokenPriceCtx() error = %v", err)
+	}
+	if _, err := client.GetTokenPriceCtx(ctx, chainId, sUSDe); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("GetTokenPriceCtx() error = %v, want context.DeadlineExceeded", err)
+	}
+
+	if len(recorder.observations) != 2 || !errors.Is(recorder.observations[1].err, context.DeadlineExceeded) {
+		t.Errorf("observations = %+v, want the rate limited call", recorder.observations)
+	}
+	if stats := client.Stats()[EndpointTokenPrice]; stats.Requests != 1 || stats.Rejected != 1 {
+		t.Errorf("Stats() = %+v, want 1 request and 1 rejected", stats)
+	}
+}
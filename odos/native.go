@@ -0,0 +1,49 @@
+package odos
+
+import "github.com/ThreeAndTwo/dex-swap-api-helper/internal/address"
+
+// NativeToken is the canonical marker for the chain native token. It is
+// translated to the zero address Odos uses for native tokens.
+const NativeToken = address.Native
+
+// _odosNativeToken is the representation of the native token in the Odos API
+const _odosNativeToken = address.Zero
+
+// IsNativeToken reports whether addr is a native token sentinel, either
+// NativeToken or the zero address
+func IsNativeToken(addr string) bool {
+	return address.IsNative(addr)
+}
+
+// toOdosToken returns the Odos representation of a token address
+func toOdosToken(addr string) string {
+	if IsNativeToken(addr) {
+		return _odosNativeToken
+	}
+	return addr
+}
+
+// withOdosTokens returns req with native token sentinels translated, copying
+// it when a translation is needed so the caller's request is left untouched
+func (r *QuoteRequest) withOdosTokens() *QuoteRequest {
+	translated := *r
+	translated.InputTokens = make([]InputToken, len(r.InputTokens))
+	translated.OutputTokens = make([]OutputToken, len(r.OutputTokens))
+
+	changed := false
+	for i, in := range r.InputTokens {
+		in.TokenAddress = toOdosToken(in.TokenAddress)
+		changed = changed || in.TokenAddress != r.InputTokens[i].TokenAddress
+		translated.InputTokens[i] = in
+	}
+	for i, out := range r.OutputTokens {
+		out.TokenAddress = toOdosToken(out.TokenAddress)
+		changed = changed || out.TokenAddress != r.OutputTokens[i].TokenAddress
+		translated.OutputTokens[i] = out
+	}
+
+	if !changed {
+		return r
+	}
+	return &translated
+}
@@ -0,0 +1,33 @@
+package odos
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestQuote_NativeToken(t *testing.T) {
+	var got QuoteRequest
+	server, client := NewTestServer(TestHandlers{
+		Quote: func(w http.ResponseWriter, r *http.Request) {
+			json.NewDecoder(r.Body).Decode(&got)
+			w.Write([]byte(cannedQuoteResponse))
+		},
+	})
+	defer server.Close()
+
+	req := NewQuoteRequest(1, nil, nil).AddInput(NativeToken, "1000000000000000000").SetSingleOutput(sUSDe)
+	if _, err := client.Quote(req); err != nil {
+		t.Fatalf("Quote() error = %v", err)
+	}
+
+	if got.InputTokens[0].TokenAddress != _odosNativeToken {
+		t.Errorf("input token = %s, want %s", got.InputTokens[0].TokenAddress, _odosNativeToken)
+	}
+	if got.OutputTokens[0].TokenAddress != sUSDe {
+		t.Errorf("output token = %s, want %s", got.OutputTokens[0].TokenAddress, sUSDe)
+	}
+	if req.InputTokens[0].TokenAddress != NativeToken {
+		t.Errorf("Quote() modified the caller's request")
+	}
+}
@@ -2,12 +2,23 @@ package odos
 
 import (
 	"bytes"
-	"encoding/json"
+	"context"
 	"fmt"
 	"io"
 	"net/http"
 	"time"
 
+	"github.com/ThreeAndTwo/dex-swap-api-helper/chains"
+	"github.com/ThreeAndTwo/dex-swap-api-helper/internal/address"
+	"github.com/ThreeAndTwo/dex-swap-api-helper/internal/breaker"
+	"github.com/ThreeAndTwo/dex-swap-api-helper/internal/codec"
+	"github.com/ThreeAndTwo/dex-swap-api-helper/internal/drain"
+	"github.com/ThreeAndTwo/dex-swap-api-helper/internal/idempotency"
+	"github.com/ThreeAndTwo/dex-swap-api-helper/internal/ratelimit"
+	"github.com/ThreeAndTwo/dex-swap-api-helper/internal/retry"
+	"github.com/ThreeAndTwo/dex-swap-api-helper/internal/stats"
+	"github.com/ThreeAndTwo/dex-swap-api-helper/internal/version"
+	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 )
 
@@ -31,21 +42,24 @@ type OutputToken struct {
 }
 
 type QuoteRequest struct {
-	ChainId              int           `json:"chainId"`
-	InputTokens          []InputToken  `json:"inputTokens"`
-	OutputTokens         []OutputToken `json:"outputTokens"`
-	GasPrice             float64       `json:"gasPrice"`
-	UserAddr             string        `json:"userAddr"`
-	SlippageLimitPercent float64       `json:"slippageLimitPercent"` // Slippage percent to use for checking if the path is valid. Float. Example: to set slippage to 0.5% send 0.5. If 1% is desired, send 1. If not provided, slippage will be set 0.3.
-	SourceBlacklist      []string      `json:"sourceBlacklist"`
-	SourceWhitelist      []string      `json:"sourceWhitelist"`
-	PoolBlacklist        []string      `json:"poolBlacklist"`
-	PathViz              bool          `json:"pathViz"`
-	ReferralCode         int           `json:"referralCode"`
-	Compact              bool          `json:"compact"`
-	LikeAsset            bool          `json:"likeAsset"`
-	DisableRFQs          bool          `json:"disableRFQs"`
-	Simple               bool          `json:"simple"` // If a less complicated quote and/or a quicker response time is desired, this flag can be set. Defaults to false
+	ChainId              chains.ChainID `json:"chainId"`
+	InputTokens          []InputToken   `json:"inputTokens"`
+	OutputTokens         []OutputToken  `json:"outputTokens"`
+	GasPrice             float64        `json:"gasPrice"`
+	UserAddr             string         `json:"userAddr,omitempty"`
+	SlippageLimitPercent float64        `json:"slippageLimitPercent"` // Slippage percent to use for checking if the path is valid. Float. Example: to set slippage to 0.5% send 0.5. If 1% is desired, send 1. If not provided, slippage will be set 0.3.
+	SourceBlacklist      []string       `json:"sourceBlacklist"`
+	SourceWhitelist      []string       `json:"sourceWhitelist"`
+	PoolBlacklist        []string       `json:"poolBlacklist"`
+	PathViz              bool           `json:"pathViz"`
+	ReferralCode         int            `json:"referralCode"`
+	Compact              bool           `json:"compact"`
+	LikeAsset            bool           `json:"likeAsset"`
+	DisableRFQs          bool           `json:"disableRFQs"`
+	Simple               bool           `json:"simple"` // If a less complicated quote and/or a quicker response time is desired, this flag can be set. Defaults to false
+
+	// likeAssetSet is set by WithLikeAsset, disabling like asset detection
+	likeAssetSet bool
 }
 
 // Token represents token information in path visualization
@@ -97,8 +111,8 @@ type QuoteResponse struct {
 	OutTokens         []string  `json:"outTokens"`
 	InAmounts         []string  `json:"inAmounts"`
 	OutAmounts        []string  `json:"outAmounts"`
-	GasEstimate       float64   `json:"gasEstimate"`
-	DataGasEstimate   int       `json:"dataGasEstimate"`
+	GasEstimate       int64     `json:"gasEstimate"` // sent as a float by Odos, rounded on decode
+	DataGasEstimate   int64     `json:"dataGasEstimate"`
 	GweiPerGas        float64   `json:"gweiPerGas"`
 	GasEstimateValue  float64   `json:"gasEstimateValue"`
 	InValues          []float64 `json:"inValues"`
@@ -110,6 +124,9 @@ type QuoteResponse struct {
 	PathId            string    `json:"pathId"`
 	PathViz           PathViz   `json:"pathViz"`
 	BlockNumber       int64     `json:"blockNumber"`
+
+	// expiresAt is when the path id expires, see PathIdExpiresAt
+	expiresAt time.Time
 }
 
 // AssembleRequest represents the request body for assemble endpoint
@@ -119,24 +136,30 @@ type AssembleRequest struct {
 	Simulate bool   `json:"simulate"`
 }
 
-// Transaction represents the transaction details in the assemble response
+// Transaction is an assembled transaction. Odos sends its numeric fields as
+// JSON numbers, decimal strings or hex strings depending on the endpoint,
+// decoding normalizes them: Value to a decimal string of wei, the others to
+// integers.
 type Transaction struct {
-	Gas      int64  `json:"gas"`
-	GasPrice int64  `json:"gasPrice"`
-	Value    string `json:"value"`
-	To       string `json:"to"`
-	From     string `json:"from"`
-	Data     string `json:"data"`
-	Nonce    int64  `json:"nonce"`
-	ChainId  int    `json:"chainId"`
+	Gas      int64          `json:"gas"`
+	GasPrice int64          `json:"gasPrice"`
+	Value    string         `json:"value"` // wei in decimal, see ValueBig
+	To       string         `json:"to"`
+	From     string         `json:"from"`
+	Data     string         `json:"data"`
+	Nonce    int64          `json:"nonce"`
+	ChainId  chains.ChainID `json:"chainId"`
+	// EIP-1559 fee fields, 0 when Odos returns a legacy transaction
+	MaxFeePerGas         int64 `json:"maxFeePerGas,omitempty"`
+	MaxPriorityFeePerGas int64 `json:"maxPriorityFeePerGas,omitempty"`
 }
 
 // Simulation represents the simulation results
 type Simulation struct {
-	IsSuccess       bool    `json:"isSuccess"`
-	AmountsOut      []int64 `json:"amountsOut"`
-	GasEstimate     int64   `json:"gasEstimate"`
-	SimulationError string  `json:"simulationError"`
+	IsSuccess       bool     `json:"isSuccess"`
+	AmountsOut      []string `json:"amountsOut"` // raw integer amounts, sent as JSON numbers by Odos
+	GasEstimate     int64    `json:"gasEstimate"`
+	SimulationError string   `json:"simulationError"`
 }
 
 // AssembleResponse represents the response from assemble endpoint
@@ -157,8 +180,36 @@ type AssembleResponse struct {
 }
 
 type OdosClient struct {
-	httpClient *http.Client
-	baseURL    string
+	httpClient    *http.Client
+	sharedHTTP    bool // httpClient is used by other clients too, see Shutdown
+	doer          Doer
+	signer        RequestSigner
+	baseURL       string
+	logger        zerolog.Logger
+	apiKey        string
+	headers       http.Header
+	customHeaders http.Header
+	recorder      Recorder
+	gasOracle     GasOracle
+	gasPrices     *gasPriceCache
+	gasBuffer     *GasBuffer
+	prices        *priceCache
+	quotes        *ttlCache[QuoteResponse]
+	quoteVer      QuoteVersion
+	capture       RawCapture
+	breaker       *breaker.Breaker
+	limiter       *ratelimit.Limiter
+	strict        bool
+	retry         *retry.Policy
+	maxAge        int64
+	tokens        *tokenCache
+	userAgent     string
+	pathReqs      *pathRequests
+	referral      int
+	stats         *stats.Tracker
+	likeAsset     bool
+	drain         *drain.Group
+	codec         Codec
 }
 
 // NewClient creates a new KyberSwap client
@@ -171,66 +222,224 @@ func NewClient(baseURL string) *OdosClient {
 		httpClient: &http.Client{
 			Timeout: 10 * time.Second,
 		},
-		baseURL: baseURL,
+		baseURL:   baseURL,
+		logger:    log.Logger,
+		quoteVer:  QuoteV2,
+		tokens:    newTokenCache(),
+		stats:     stats.New(),
+		drain:     drain.New(),
+		codec:     codec.Standard,
+		userAgent: version.UserAgent,
+		pathReqs:  newPathRequests(_pathRequestsMaxEntries),
+		headers: http.Header{
+			"Origin":  {"https://app.odos.xyz"},
+			"Referer": {"https://app.odos.xyz/"},
+		},
+	}
+}
+
+// newRequest creates a request carrying the headers common to every call
+func (c *OdosClient) newRequest(method, url string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequest(method, url, body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("User-Agent", c.userAgent)
+	if c.apiKey != "" {
+		req.Header.Set(_apiKeyHeader, c.apiKey)
 	}
+	for key, values := range c.customHeaders {
+		req.Header[key] = values
+	}
+	return req, nil
+}
+
+// setJSONHeaders sets the headers of the JSON POST requests, the configured
+// headers on top of the content negotiation ones and the headers set with
+// WithHeader on top of both
+func (c *OdosClient) setJSONHeaders(req *http.Request) {
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "*/*")
+
+	for key, values := range c.headers {
+		if len(values) == 0 {
+			req.Header.Del(key)
+			continue
+		}
+		req.Header[key] = values
+	}
+	for key, values := range c.customHeaders {
+		req.Header[key] = values
+	}
+}
+
+func (c *OdosClient) GetTokenPrice(chainID chains.ChainID, tokenAddr string) (*PriceResponse, error) {
+	return c.GetTokenPriceCtx(context.Background(), chainID, tokenAddr)
 }
 
-func (c *OdosClient) GetTokenPrice(chainID, tokenAddr string) (*PriceResponse, error) {
-	url := fmt.Sprintf("%s/pricing/token/%s/%s", c.baseURL, chainID, tokenAddr)
-	log.Info().Msgf("url: %s", url)
+// GetTokenPriceCtx is GetTokenPrice with a context cancelling the request
+func (c *OdosClient) GetTokenPriceCtx(ctx context.Context, chainID chains.ChainID, tokenAddr string) (*PriceResponse, error) {
+	tokenAddr, err := address.Normalize(toOdosToken(tokenAddr))
+	if err != nil {
+		return nil, err
+	}
+
+	if c.prices != nil {
+		if price, ok := c.prices.get(priceCacheKey(chainID, tokenAddr)); ok {
+			return price, nil
+		}
+	}
+
+	url := fmt.Sprintf("%s/pricing/token/%d/%s", c.baseURL, chainID, tokenAddr)
+	c.logger.Debug().
+		Str("endpoint", EndpointTokenPrice).
+		Int64("chain", int64(chainID)).
+		Str("token", tokenAddr).
+		Msg("sending request")
+
+	request, err := c.newRequest("GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
 
-	resp, err := c.httpClient.Get(url)
+	resp, err := c.do(EndpointTokenPrice, request.WithContext(ctx))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get token price: %w", err)
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode != http.StatusOK {
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("status code %d, failed to read error response: %w", resp.StatusCode, err)
+		}
+		return nil, newAPIError(resp.StatusCode, body)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
 	var priceResp PriceResponse
-	if err := json.NewDecoder(resp.Body).Decode(&priceResp); err != nil {
+	if err := c.decodeStrict(resp.StatusCode, body, &priceResp); err != nil {
 		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
 
+	if !(priceResp.Price > 0) {
+		return nil, fmt.Errorf("%w: %s on chain %d", ErrNoPrice, tokenAddr, chainID)
+	}
+
+	if c.prices != nil {
+		c.prices.set(priceCacheKey(chainID, tokenAddr), priceResp)
+	}
+
 	return &priceResp, nil
 }
 
 // Generate Odos Quote
-// /sor/quote/v2
+// /sor/quote/v2, or the version set by WithQuoteVersion
 func (c *OdosClient) Quote(req *QuoteRequest) (*QuoteResponse, error) {
-	url := fmt.Sprintf("%s/sor/quote/v2", c.baseURL)
+	quoteResp, _, err := c.QuoteWithMeta(req)
+	return quoteResp, err
+}
+
+// QuoteWithMeta generates an Odos quote and returns the response headers
+// along with it, also when the request fails with a status error
+func (c *OdosClient) QuoteWithMeta(req *QuoteRequest) (*QuoteResponse, http.Header, error) {
+	url := fmt.Sprintf("%s/sor/quote/%s", c.baseURL, c.quoteVer)
+
+	if req.ReferralCode == 0 && c.referral != 0 {
+		referred := *req
+		referred.ReferralCode = c.referral
+		req = &referred
+	}
 
-	jsonData, err := json.Marshal(req)
+	if err := req.Validate(); err != nil {
+		return nil, nil, err
+	}
+	req = c.withDetectedLikeAsset(req)
+	req = req.withOdosTokens()
+
+	if req.GasPrice == 0 && c.gasPrices != nil {
+		gasPrice, err := c.gasPrice(req.ChainId)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get gas price: %w", err)
+		}
+
+		filled := *req
+		filled.GasPrice = gasPrice
+		req = &filled
+	}
+
+	jsonData, err := c.codec.Marshal(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request: %w", err)
+		return nil, nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	request, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonData))
+	c.logger.Debug().
+		Str("endpoint", EndpointQuote).
+		Int64("chain", int64(req.ChainId)).
+		Str("user_addr", address.Redact(req.UserAddr)).
+		Msg("sending request")
+
+	request, err := c.newRequest("POST", url, bytes.NewBuffer(jsonData))
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	// Set headers
-	request.Header.Set("Content-Type", "application/json")
-	request.Header.Set("Accept", "*/*")
-	request.Header.Set("Origin", "https://app.odos.xyz")
-	request.Header.Set("Referer", "https://app.odos.xyz/")
+	c.setJSONHeaders(request)
 
-	resp, err := c.httpClient.Do(request)
+	resp, err := c.do(EndpointQuote, request)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get quote: %w", err)
+		return nil, nil, fmt.Errorf("failed to get quote: %w", err)
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode != http.StatusOK {
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, resp.Header, fmt.Errorf("status code %d, failed to read error response: %w", resp.StatusCode, err)
+		}
+		return nil, resp.Header, newAPIError(resp.StatusCode, body)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, resp.Header, fmt.Errorf("failed to read response body: %w", err)
+	}
+
 	var quoteResp QuoteResponse
-	if err := json.NewDecoder(resp.Body).Decode(&quoteResp); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	if err := c.decodeStrict(resp.StatusCode, body, &quoteResp); err != nil {
+		return nil, resp.Header, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	quoteResp.expiresAt = time.Now().Add(PathIdTTL)
+	if quoteResp.PathId != "" && !req.PathViz {
+		c.pathReqs.add(quoteResp.PathId, req)
 	}
 
-	return &quoteResp, nil
+	return &quoteResp, resp.Header, nil
 }
 
 // /sor/assemble
 // Assemble Odos quote into transaction
 func (c *OdosClient) Assemble(userAddr, pathId string, isSimulate bool) (*AssembleResponse, error) {
+	assembleResp, _, err := c.AssembleWithMeta(userAddr, pathId, isSimulate)
+	return assembleResp, err
+}
+
+// AssembleWithMeta assembles an Odos quote into transaction and returns the
+// response headers along with it, also when the request fails with a status error
+func (c *OdosClient) AssembleWithMeta(userAddr, pathId string, isSimulate bool) (*AssembleResponse, http.Header, error) {
+	return c.assemble(userAddr, pathId, isSimulate, idempotency.NewKey())
+}
+
+// assemble assembles pathId, sending idempotencyKey in the Idempotency-Key
+// header
+func (c *OdosClient) assemble(userAddr, pathId string, isSimulate bool, idempotencyKey string) (*AssembleResponse, http.Header, error) {
 	url := fmt.Sprintf("%s/sor/assemble", c.baseURL)
 
 	req := AssembleRequest{
@@ -238,47 +447,55 @@ func (c *OdosClient) Assemble(userAddr, pathId string, isSimulate bool) (*Assemb
 		PathId:   pathId,
 		Simulate: isSimulate,
 	}
+	if err := req.Validate(); err != nil {
+		return nil, nil, err
+	}
 
-	jsonData, err := json.Marshal(req)
+	jsonData, err := c.codec.Marshal(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request: %w", err)
+		return nil, nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	request, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonData))
+	request, err := c.newRequest("POST", url, bytes.NewBuffer(jsonData))
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	// Set headers
-	request.Header.Set("Content-Type", "application/json")
-	request.Header.Set("Accept", "*/*")
-	request.Header.Set("Origin", "https://app.odos.xyz")
-	request.Header.Set("Referer", "https://app.odos.xyz/")
+	c.setJSONHeaders(request)
+	request.Header.Set(idempotency.Header, idempotencyKey)
 
-	resp, err := c.httpClient.Do(request)
+	resp, err := c.do(EndpointAssemble, request)
 	if err != nil {
-		return nil, fmt.Errorf("failed to assemble transaction: %w", err)
+		return nil, nil, fmt.Errorf("failed to assemble transaction: %w", err)
 	}
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+		return nil, resp.Header, fmt.Errorf("failed to read response body: %w", err)
 	}
 
-	log.Info().Msgf("response body: %s", string(body))
+	c.logger.Debug().
+		Str("endpoint", EndpointAssemble).
+		Str("user_addr", address.Redact(userAddr)).
+		Int("status_code", resp.StatusCode).
+		Int("body_size", len(body)).
+		Msg("received response")
 
 	if resp.StatusCode != http.StatusOK {
-		log.Error().
+		c.logger.Error().
+			Str("endpoint", EndpointAssemble).
 			Int("status_code", resp.StatusCode).
 			Str("response_body", string(body)).
 			Msg("Assemble request failed")
-		return nil, fmt.Errorf("unexpected status code: %d, response: %s", resp.StatusCode, string(body))
+		return nil, resp.Header, newAPIError(resp.StatusCode, body)
 	}
 
 	var assembleResp AssembleResponse
-	if err := json.Unmarshal(body, &assembleResp); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	if err := c.decodeStrict(resp.StatusCode, body, &assembleResp); err != nil {
+		return nil, resp.Header, fmt.Errorf("failed to decode response: %w", err)
 	}
-	return &assembleResp, nil
+	c.applyGasBuffer(&assembleResp)
+	return &assembleResp, resp.Header, nil
 }
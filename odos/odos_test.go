@@ -3,10 +3,12 @@ package odos
 import (
 	"encoding/json"
 	"testing"
+
+	"github.com/ThreeAndTwo/dex-swap-api-helper/chains"
 )
 
 const (
-	chainId = "1"
+	chainId = chains.Ethereum
 	sUSDe   = "0x9D39A5DE30e57443BfF2A8307A4256c8797A3497"
 	DAI     = "0x6B175474E89094C44Da98b954EedeAC495271d0F"
 
@@ -22,7 +24,7 @@ func init() {
 
 func TestGetTokenPrice(t *testing.T) {
 	type args struct {
-		chainID   string
+		chainID   chains.ChainID
 		tokenAddr string
 	}
 
@@ -96,7 +98,7 @@ func TestQuote(t *testing.T) {
 				SourceBlacklist:      []string{},
 				SourceWhitelist:      []string{},
 				PoolBlacklist:        []string{},
-				PathViz:              true,
+				PathViz:              false,
 				ReferralCode:         1,
 				Compact:              true,
 				LikeAsset:            true,
@@ -127,7 +129,7 @@ func TestQuote(t *testing.T) {
 				SourceBlacklist:      []string{},
 				SourceWhitelist:      []string{},
 				PoolBlacklist:        []string{},
-				PathViz:              true,
+				PathViz:              false,
 				ReferralCode:         1,
 				Compact:              true,
 				LikeAsset:            true,
@@ -158,7 +160,7 @@ func TestQuote(t *testing.T) {
 				SourceBlacklist:      []string{},
 				SourceWhitelist:      []string{},
 				PoolBlacklist:        []string{},
-				PathViz:              true,
+				PathViz:              false,
 				ReferralCode:         1,
 				Compact:              true,
 				LikeAsset:            true,
@@ -0,0 +1,132 @@
+package odos
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// _apiKeyHeader is the header carrying the Odos API key
+const _apiKeyHeader = "X-API-Key"
+
+// Option configures an OdosClient
+type Option func(*OdosClient)
+
+// NewClientWithOptions creates a new Odos client configured by opts, applied
+// in order on top of the defaults of NewClient
+func NewClientWithOptions(opts ...Option) *OdosClient {
+	c := NewClient("")
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// WithBaseURL sets the Odos API base URL, an empty URL keeps the default
+func WithBaseURL(baseURL string) Option {
+	return func(c *OdosClient) {
+		if baseURL != "" {
+			c.baseURL = baseURL
+		}
+	}
+}
+
+// WithTimeout sets the timeout of the HTTP client
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *OdosClient) {
+		c.ownHTTPClient().Timeout = timeout
+	}
+}
+
+// WithHTTPClient replaces the HTTP client used for requests, e.g. to route
+// through a proxy or customize TLS with a transport of its own:
+//
+//	WithHTTPClient(&http.Client{Transport: &http.Transport{
+//		Proxy:           http.ProxyFromEnvironment,
+//		TLSClientConfig: &tls.Config{RootCAs: proxyCAs},
+//	}})
+//
+// Clients given the same HTTP client share its connection pool.
+// WithTransportConfig, WithTimeouts and WithInsecureTLS give a client a
+// transport of its own, ending the sharing; configure the transport of the
+// shared HTTP client instead to keep it.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *OdosClient) {
+		c.httpClient = httpClient
+		c.sharedHTTP = true
+		c.doer = nil
+	}
+}
+
+// WithLogger sets the logger used by the client instead of the global logger
+func WithLogger(logger zerolog.Logger) Option {
+	return func(c *OdosClient) {
+		c.logger = logger
+	}
+}
+
+// WithAPIKey sends apiKey with every request
+func WithAPIKey(apiKey string) Option {
+	return func(c *OdosClient) {
+		c.apiKey = apiKey
+	}
+}
+
+// WithHeaders overrides the headers sent with quote and assemble requests,
+// by default Origin and Referer pointing at app.odos.xyz. A header with no
+// values is removed, e.g. http.Header{"Origin": nil} drops Origin.
+// Content-Type and Accept can't be overridden.
+func WithHeaders(headers http.Header) Option {
+	return func(c *OdosClient) {
+		for key, values := range headers {
+			key = http.CanonicalHeaderKey(key)
+			if key == "Content-Type" || key == "Accept" {
+				continue
+			}
+			c.headers[key] = values
+		}
+	}
+}
+
+// QuoteVersion selects the version of the Odos SOR quote endpoint
+type QuoteVersion string
+
+const (
+	// QuoteV2 targets /sor/quote/v2, the default
+	QuoteV2 QuoteVersion = "v2"
+	// QuoteV3 targets /sor/quote/v3, which has the improved routing
+	QuoteV3 QuoteVersion = "v3"
+)
+
+// WithQuoteVersion selects the SOR quote endpoint version used by Quote, an
+// empty version keeps the default QuoteV2
+func WithQuoteVersion(version QuoteVersion) Option {
+	return func(c *OdosClient) {
+		if version != "" {
+			c.quoteVer = version
+		}
+	}
+}
+
+// WithUserAgent sets the User-Agent of every request, an empty ua keeps the
+// default identifying this library and its version
+func WithUserAgent(ua string) Option {
+	return func(c *OdosClient) {
+		if ua != "" {
+			c.userAgent = ua
+		}
+	}
+}
+
+// WithHeader sends the header key with value on every request, e.g.
+// Accept-Language or a header required by a gateway. Calls accumulate, a
+// later call with the same key replaces its value.
+func WithHeader(key, value string) Option {
+	return func(c *OdosClient) {
+		if c.customHeaders == nil {
+			c.customHeaders = make(http.Header)
+		}
+		c.customHeaders.Set(key, value)
+	}
+}
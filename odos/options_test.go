@@ -0,0 +1,179 @@
+package odos
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+func TestNewClientWithOptions(t *testing.T) {
+	var apiKey string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		apiKey = r.Header.Get(_apiKeyHeader)
+		w.Write([]byte(`{"currencyId":"USD","price":1}`))
+	}))
+	defer server.Close()
+
+	var logs bytes.Buffer
+	httpClient := &http.Client{}
+	client := NewClientWithOptions(
+		WithBaseURL(server.URL),
+		WithHTTPClient(httpClient),
+		WithTimeout(3*time.Second),
+		WithLogger(zerolog.New(&logs)),
+		WithAPIKey("secret"),
+	)
+
+	if client.httpClient.Timeout != 3*time.Second || httpClient.Timeout != 0 {
+		t.Errorf("http client not configured on a copy: %+v, passed %+v", client.httpClient, httpClient)
+	}
+
+	if _, err := client.GetTokenPrice(chainId, DAI); err != nil {
+		t.Fatalf("GetTokenPrice() error = %v", err)
+	}
+	if apiKey != "secret" {
+		t.Errorf("%s = %q, want secret", _apiKeyHeader, apiKey)
+	}
+	if logs.Len() == 0 {
+		t.Errorf("expected the configured logger to be used")
+	}
+}
+
+func TestNewClientWithOptions_Defaults(t *testing.T) {
+	client := NewClientWithOptions(WithBaseURL(""))
+	if client.baseURL != _baseURL {
+		t.Errorf("baseURL = %s, want %s", client.baseURL, _baseURL)
+	}
+	if client.httpClient.Timeout != 10*time.Second {
+		t.Errorf("timeout = %v, want 10s", client.httpClient.Timeout)
+	}
+}
+
+func TestWithHeaders(t *testing.T) {
+	var got http.Header
+	server, client := NewTestServer(TestHandlers{
+		Quote: func(w http.ResponseWriter, r *http.Request) {
+			got = r.Header
+			w.Write([]byte(`{"pathId":"abc"}`))
+		},
+	}, WithHeaders(http.Header{
+		"origin":       nil,
+		"Referer":      {"https://example.com/"},
+		"X-Gateway":    {"odos"},
+		"Content-Type": {"text/plain"},
+	}))
+	defer server.Close()
+
+	if _, err := client.Quote(NewQuoteRequest(1, nil, nil)); err != nil {
+		t.Fatalf("Quote() error = %v", err)
+	}
+
+	want := map[string]string{
+		"Origin":       "",
+		"Referer":      "https://example.com/",
+		"X-Gateway":    "odos",
+		"Content-Type": "application/json",
+		"Accept":       "*/*",
+	}
+	for key, value := range want {
+		if got.Get(key) != value {
+			t.Errorf("header %s = %q, want %q", key, got.Get(key), value)
+		}
+	}
+}
+
+func TestWithHeader(t *testing.T) {
+	var got http.Header
+	server, client := NewTestServer(TestHandlers{
+		Price: func(w http.ResponseWriter, r *http.Request) {
+			got = r.Header
+			w.Write([]byte(cannedPriceResponse))
+		},
+	}, WithHeader("Accept-Language", "de"), WithHeader("X-Region", "eu"), WithHeader("accept-language", "en-US"))
+	defer server.Close()
+
+	if _, err := client.GetTokenPrice(chainId, DAI); err != nil {
+		t.Fatalf("GetTokenPrice() error = %v", err)
+	}
+	if got.Get("Accept-Language") != "en-US" || got.Get("X-Region") != "eu" {
+		t.Errorf("headers = %v, want Accept-Language en-US and X-Region eu", got)
+	}
+}
+
+func TestWithHeader_Quote(t *testing.T) {
+	var got http.Header
+	server, client := NewTestServer(TestHandlers{
+		Quote: func(w http.ResponseWriter, r *http.Request) {
+			got = r.Header
+			w.Write([]byte(`{"pathId":"abc"}`))
+		},
+	}, WithHeader("Origin", "https://example.com"), WithHeader("Accept", "application/json"))
+	defer server.Close()
+
+	if _, err := client.Quote(NewQuoteRequest(1, nil, nil)); err != nil {
+		t.Fatalf("Quote() error = %v", err)
+	}
+	if got.Get("Origin") != "https://example.com" || got.Get("Accept") != "application/json" {
+		t.Errorf("headers = %v, want the Origin and Accept set with WithHeader", got)
+	}
+}
+
+func TestWithQuoteVersion(t *testing.T) {
+	tests := []struct {
+		version QuoteVersion
+		want    string
+	}{
+		{"", "/sor/quote/v2"},
+		{QuoteV2, "/sor/quote/v2"},
+		{QuoteV3, "/sor/quote/v3"},
+	}
+
+	for _, tt := range tests {
+		var path string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			path = r.URL.Path
+			w.Write([]byte(cannedQuoteResponse))
+		}))
+
+		client := NewClientWithOptions(WithBaseURL(server.URL), WithQuoteVersion(tt.version))
+		req := NewQuoteRequest(1, nil, nil).AddInput(DAI, "1000000000000000000").SetSingleOutput(sUSDe)
+		if _, err := client.Quote(req); err != nil {
+			t.Fatalf("Quote() error = %v", err)
+		}
+		server.Close()
+
+		if path != tt.want {
+			t.Errorf("WithQuoteVersion(%q) path = %s, want %s", tt.version, path, tt.want)
+		}
+	}
+}
+
+func TestWithUserAgent(t *testing.T) {
+	var userAgent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgent = r.Header.Get("User-Agent")
+		w.Write([]byte(cannedPriceResponse))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	if _, err := client.GetTokenPrice(chainId, DAI); err != nil {
+		t.Fatalf("GetTokenPrice() error = %v", err)
+	}
+	if !strings.HasPrefix(userAgent, "dex-swap-api-helper/") {
+		t.Errorf("default User-Agent = %q", userAgent)
+	}
+
+	client = NewClientWithOptions(WithBaseURL(server.URL), WithUserAgent("my-bot/1.0"))
+	if _, err := client.GetTokenPrice(chainId, DAI); err != nil {
+		t.Fatalf("GetTokenPrice() error = %v", err)
+	}
+	if userAgent != "my-bot/1.0" {
+		t.Errorf("User-Agent = %q, want my-bot/1.0", userAgent)
+	}
+}
@@ -0,0 +1,23 @@
+package odos
+
+import "time"
+
+// PathIdTTL is how long Odos keeps the path id of a quote assemblable. The
+// quote response carries no expiry, this is the validity Odos documents.
+const PathIdTTL = 60 * time.Second
+
+// PathIdExpiresAt returns when the path id of the quote expires, PathIdTTL
+// after the quote was received. It is the zero time for quotes that weren't
+// received from Quote, e.g. decoded from a stored response.
+func (q *QuoteResponse) PathIdExpiresAt() time.Time {
+	return q.expiresAt
+}
+
+// PathIdRemaining returns how long the path id of the quote stays valid, 0
+// once it expired and PathIdTTL when the expiry is unknown
+func (q *QuoteResponse) PathIdRemaining() time.Duration {
+	if q.expiresAt.IsZero() {
+		return PathIdTTL
+	}
+	return max(time.Until(q.expiresAt), 0)
+}
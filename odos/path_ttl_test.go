@@ -0,0 +1,46 @@
+package odos
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestQuote_PathIdExpiresAt(t *testing.T) {
+	var assembled bool
+	server, client := NewTestServer(TestHandlers{
+		Assemble: func(w http.ResponseWriter, r *http.Request) {
+			assembled = true
+			w.Write([]byte(cannedAssembleResponse))
+		},
+	})
+	defer server.Close()
+
+	before := time.Now()
+	quote, err := client.Quote(NewQuoteRequest(1, []InputToken{{TokenAddress: DAI, Amount: "1000"}}, nil).SetSingleOutput(sUSDe).WithUserAddr(testUserAddr))
+	if err != nil {
+		t.Fatalf("Quote() error = %v", err)
+	}
+	if expiresAt := quote.PathIdExpiresAt(); expiresAt.Before(before.Add(PathIdTTL)) || expiresAt.After(time.Now().Add(PathIdTTL)) {
+		t.Errorf("PathIdExpiresAt() = %v, want %v after the quote", expiresAt, PathIdTTL)
+	}
+	if remaining := quote.PathIdRemaining(); remaining <= 0 || remaining > PathIdTTL {
+		t.Errorf("PathIdRemaining() = %v", remaining)
+	}
+
+	quote.expiresAt = time.Now().Add(-time.Second)
+	if remaining := quote.PathIdRemaining(); remaining != 0 {
+		t.Errorf("PathIdRemaining() of an expired quote = %v, want 0", remaining)
+	}
+	if _, err := client.AssembleQuote(testUserAddr, quote, false); !errors.Is(err, ErrPathExpired) {
+		t.Errorf("AssembleQuote() error = %v, want ErrPathExpired", err)
+	}
+	if assembled {
+		t.Error("AssembleQuote() sent an expired path id")
+	}
+
+	if remaining := (&QuoteResponse{}).PathIdRemaining(); remaining != PathIdTTL {
+		t.Errorf("PathIdRemaining() with no expiry = %v, want %v", remaining, PathIdTTL)
+	}
+}
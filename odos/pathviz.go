@@ -0,0 +1,101 @@
+package odos
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+)
+
+// PathVizContentType is the content type of the image rendered by RenderSVG
+const PathVizContentType = "image/svg+xml"
+
+// Layout of the rendered path graph
+const (
+	_vizColumnWidth = 220
+	_vizRowHeight   = 70
+	_vizNodeWidth   = 110
+	_vizNodeHeight  = 34
+	_vizMargin      = 20
+)
+
+// RenderSVG draws the path visualization returned with a quote requested
+// with PathViz set, tokens laid out left to right by hop, links labelled with
+// the liquidity source and the share of value routed through them. It returns
+// the image with its content type.
+func (p PathViz) RenderSVG() ([]byte, string) {
+	columns := p.nodeColumns()
+
+	rows := make(map[int]int)
+	x := make([]int, len(p.Nodes))
+	y := make([]int, len(p.Nodes))
+	width, height := 0, 0
+	for i := range p.Nodes {
+		x[i] = _vizMargin + columns[i]*_vizColumnWidth
+		y[i] = _vizMargin + rows[columns[i]]*_vizRowHeight
+		rows[columns[i]]++
+
+		width = max(width, x[i]+_vizNodeWidth+_vizMargin)
+		height = max(height, y[i]+_vizNodeHeight+_vizMargin)
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" font-family="sans-serif" font-size="12">`, width, height)
+	for _, link := range p.Links {
+		if !p.validNode(link.Source) || !p.validNode(link.Target) {
+			continue
+		}
+
+		x1, y1 := x[link.Source]+_vizNodeWidth, y[link.Source]+_vizNodeHeight/2
+		x2, y2 := x[link.Target], y[link.Target]+_vizNodeHeight/2
+		fmt.Fprintf(&buf, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="#8a8fa3" stroke-width="2"/>`, x1, y1, x2, y2)
+		fmt.Fprintf(&buf, `<text x="%d" y="%d" text-anchor="middle" fill="#555">%s %.1f%%</text>`,
+			(x1+x2)/2, (y1+y2)/2-4, html.EscapeString(link.Label), link.Value)
+	}
+	for i, node := range p.Nodes {
+		fmt.Fprintf(&buf, `<rect x="%d" y="%d" width="%d" height="%d" rx="6" fill="#eef0f8" stroke="#3b4cca"/>`,
+			x[i], y[i], _vizNodeWidth, _vizNodeHeight)
+		fmt.Fprintf(&buf, `<text x="%d" y="%d" text-anchor="middle">%s</text>`,
+			x[i]+_vizNodeWidth/2, y[i]+_vizNodeHeight/2+4, html.EscapeString(node.Symbol))
+	}
+	buf.WriteString(`</svg>`)
+
+	return buf.Bytes(), PathVizContentType
+}
+
+// nodeColumns returns the hop of every node, the length of the longest
+// path of links leading to it
+func (p PathViz) nodeColumns() []int {
+	columns := make([]int, len(p.Nodes))
+	// relax the links until stable, bounded by the node count to stay safe
+	// on cyclic input
+	for pass := 0; pass < len(p.Nodes); pass++ {
+		changed := false
+		for _, link := range p.Links {
+			if !p.validNode(link.Source) || !p.validNode(link.Target) {
+				continue
+			}
+			if columns[link.Target] < columns[link.Source]+1 {
+				columns[link.Target] = columns[link.Source] + 1
+				changed = true
+			}
+		}
+		if !changed {
+			break
+		}
+	}
+	return columns
+}
+
+func (p PathViz) validNode(i int) bool {
+	return i >= 0 && i < len(p.Nodes)
+}
+
+// Hops returns the number of hops of the longest path from an input to an
+// output token, 0 when the visualization is empty
+func (p PathViz) Hops() int {
+	hops := 0
+	for _, column := range p.nodeColumns() {
+		hops = max(hops, column)
+	}
+	return hops
+}
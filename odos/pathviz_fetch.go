@@ -0,0 +1,72 @@
+package odos
+
+import (
+	"fmt"
+	"sync"
+)
+
+// _pathRequestsMaxEntries bounds the number of quotes GetPathViz can fetch
+// the visualization of, older ones are forgotten first
+const _pathRequestsMaxEntries = 1024
+
+// pathRequests remembers the requests of recent quotes by path id
+type pathRequests struct {
+	mu         sync.Mutex
+	maxEntries int
+	order      []string
+	reqs       map[string]*QuoteRequest
+}
+
+func newPathRequests(maxEntries int) *pathRequests {
+	return &pathRequests{
+		maxEntries: maxEntries,
+		reqs:       make(map[string]*QuoteRequest),
+	}
+}
+
+func (p *pathRequests) add(pathId string, req *QuoteRequest) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, ok := p.reqs[pathId]; ok {
+		return
+	}
+	if len(p.order) >= p.maxEntries {
+		delete(p.reqs, p.order[0])
+		p.order = p.order[1:]
+	}
+
+	stored := *req
+	p.reqs[pathId] = &stored
+	p.order = append(p.order, pathId)
+}
+
+func (p *pathRequests) get(pathId string) (*QuoteRequest, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	req, ok := p.reqs[pathId]
+	return req, ok
+}
+
+// GetPathViz fetches the path visualization of a quote made without
+// PathViz, e.g. when a user expands the route. Odos has no endpoint serving
+// the visualization of an existing path, so the request of the quote is
+// quoted again with PathViz set: the graph shows the current best path,
+// which may differ from pathId when the market moved. It fails with
+// ErrPathExpired for a path id not quoted by this client recently.
+func (c *OdosClient) GetPathViz(pathId string) (*PathViz, error) {
+	req, ok := c.pathReqs.get(pathId)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s not quoted by this client", ErrPathExpired, pathId)
+	}
+
+	vizReq := *req
+	vizReq.PathViz = true
+	quoteResp, err := c.Quote(&vizReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return &quoteResp.PathViz, nil
+}
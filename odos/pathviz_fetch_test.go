@@ -0,0 +1,59 @@
+package odos
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestGetPathViz(t *testing.T) {
+	var pathVizSent []bool
+	server, client := NewTestServer(TestHandlers{
+		Quote: func(w http.ResponseWriter, r *http.Request) {
+			var req QuoteRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			pathVizSent = append(pathVizSent, req.PathViz)
+			if req.PathViz {
+				fmt.Fprint(w, `{"pathId":"viz","pathViz":{"nodes":[{"symbol":"DAI"},{"symbol":"sUSDe"}],"links":[{"source":0,"target":1}]}}`)
+				return
+			}
+			w.Write([]byte(cannedQuoteResponse))
+		},
+	})
+	defer server.Close()
+
+	quoteResp, err := client.Quote(NewQuoteRequest(1, nil, nil).AddInput(DAI, "1000000000000000000").SetSingleOutput(sUSDe))
+	if err != nil {
+		t.Fatalf("Quote() error = %v", err)
+	}
+
+	viz, err := client.GetPathViz(quoteResp.PathId)
+	if err != nil {
+		t.Fatalf("GetPathViz() error = %v", err)
+	}
+	if len(viz.Nodes) != 2 || viz.Nodes[1].Symbol != "sUSDe" {
+		t.Errorf("GetPathViz() = %+v", viz)
+	}
+	if len(pathVizSent) != 2 || pathVizSent[0] || !pathVizSent[1] {
+		t.Errorf("pathViz sent = %v, want [false true]", pathVizSent)
+	}
+
+	if _, err := client.GetPathViz("unknown"); !errors.Is(err, ErrPathExpired) {
+		t.Errorf("GetPathViz(unknown) error = %v, want ErrPathExpired", err)
+	}
+}
+
+func TestPathRequests_Bounded(t *testing.T) {
+	p := newPathRequests(2)
+	for _, id := range []string{"a", "b", "c"} {
+		p.add(id, &QuoteRequest{})
+	}
+	if _, ok := p.get("a"); ok {
+		t.Errorf("oldest path kept beyond the bound")
+	}
+	if _, ok := p.get("c"); !ok {
+		t.Errorf("newest path dropped")
+	}
+}
@@ -0,0 +1,37 @@
+package odos
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPathViz_RenderSVG(t *testing.T) {
+	viz := PathViz{
+		Nodes: []Token{{Symbol: "DAI"}, {Symbol: "USDC"}, {Symbol: "sUSDe"}},
+		Links: []PathLink{
+			{Source: 0, Target: 1, Label: "Curve", Value: 100},
+			{Source: 1, Target: 2, Label: "Uniswap V3", Value: 60},
+			{Source: 0, Target: 2, Label: "<Ethena>", Value: 40},
+			{Source: 2, Target: 9, Label: "dangling"},
+		},
+	}
+
+	if got := viz.nodeColumns(); got[0] != 0 || got[1] != 1 || got[2] != 2 {
+		t.Errorf("nodeColumns() = %v, want [0 1 2]", got)
+	}
+
+	img, contentType := viz.RenderSVG()
+	if contentType != PathVizContentType {
+		t.Errorf("RenderSVG() content type = %s", contentType)
+	}
+
+	svg := string(img)
+	for _, want := range []string{"<svg", "sUSDe", "Uniswap V3 60.0%", "&lt;Ethena&gt;"} {
+		if !strings.Contains(svg, want) {
+			t.Errorf("RenderSVG() missing %q", want)
+		}
+	}
+	if strings.Contains(svg, "dangling") {
+		t.Errorf("RenderSVG() drew a link to an unknown node")
+	}
+}
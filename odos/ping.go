@@ -0,0 +1,36 @@
+package odos
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// Ping checks Odos is reachable and healthy with the cheap chains endpoint,
+// returning nil when the service is up
+// /info/chains
+func (c *OdosClient) Ping(ctx context.Context) error {
+	url := fmt.Sprintf("%s/info/chains", c.baseURL)
+
+	request, err := c.newRequest("GET", url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.do(EndpointPing, request.WithContext(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to ping: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return newAPIError(resp.StatusCode, body)
+	}
+	return nil
+}
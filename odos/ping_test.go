@@ -0,0 +1,34 @@
+package odos
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestPing(t *testing.T) {
+	status := http.StatusOK
+	server, client := NewTestServer(TestHandlers{
+		Chains: func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(status)
+			w.Write([]byte(cannedChainsResponse))
+		},
+	})
+	defer server.Close()
+
+	if err := client.Ping(context.Background()); err != nil {
+		t.Fatalf("Ping() error = %v", err)
+	}
+
+	status = http.StatusServiceUnavailable
+	if err := client.Ping(context.Background()); err == nil {
+		t.Errorf("Ping() succeeded on %d", status)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	status = http.StatusOK
+	if err := client.Ping(ctx); err == nil {
+		t.Errorf("Ping() succeeded with a canceled context")
+	}
+}
@@ -0,0 +1,199 @@
+package odos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/ThreeAndTwo/dex-swap-api-helper/chains"
+	"github.com/ThreeAndTwo/dex-swap-api-helper/internal/address"
+)
+
+const (
+	// MaxPricesPerRequest is the number of tokens GetTokenPrices prices per
+	// request, keeping the query string under the URL length servers accept
+	MaxPricesPerRequest = 50
+
+	// _priceBatchConcurrency is the number of batch requests GetTokenPrices
+	// keeps in flight
+	_priceBatchConcurrency = 4
+)
+
+// batchPriceResponse is the response of the batch pricing endpoint
+type batchPriceResponse struct {
+	CurrencyId  string             `json:"currencyId"`
+	TokenPrices map[string]float64 `json:"tokenPrices"`
+}
+
+// GetTokenPricesCtx prices tokenAddrs with at most concurrency requests in
+// flight, going through the rate limit set with WithRateLimit. Prices and
+// errors are returned in the order of tokenAddrs. When ctx is done no new
+// request is started: the prices collected so far are returned and the
+// tokens left unpriced carry the context error.
+func (c *OdosClient) GetTokenPricesCtx(ctx context.Context, chainID chains.ChainID, tokenAddrs []string, concurrency int) ([]*PriceResponse, []error) {
+	prices := make([]*PriceResponse, len(tokenAddrs))
+	errs := make([]error, len(tokenAddrs))
+
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+	if concurrency > len(tokenAddrs) {
+		concurrency = len(tokenAddrs)
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				if err := ctx.Err(); err != nil {
+					errs[i] = err
+					continue
+				}
+				prices[i], errs[i] = c.GetTokenPriceCtx(ctx, chainID, tokenAddrs[i])
+			}
+		}()
+	}
+
+	next := 0
+feed:
+	for ; next < len(tokenAddrs); next++ {
+		select {
+		case jobs <- next:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	for i := next; i < len(tokenAddrs); i++ {
+		errs[i] = ctx.Err()
+	}
+	return prices, errs
+}
+
+// GetTokenPrices prices tokenAddrs with the batch pricing endpoint. The
+// tokens are split in chunks of MaxPricesPerRequest sent concurrently,
+// going through the rate limit set with WithRateLimit, and the prices are
+// merged into one map keyed by the addresses as passed. Tokens Odos has no
+// price for are left out of the map. When some chunks fail the prices of
+// the others are returned along with the error.
+func (c *OdosClient) GetTokenPrices(chainID chains.ChainID, tokenAddrs []string) (map[string]float64, error) {
+	return c.GetTokenPricesBatchCtx(context.Background(), chainID, tokenAddrs)
+}
+
+// GetTokenPricesBatchCtx is GetTokenPrices with a context. Prices held by
+// the cache set with WithPriceCache are served from it, the others are
+// added to it.
+func (c *OdosClient) GetTokenPricesBatchCtx(ctx context.Context, chainID chains.ChainID, tokenAddrs []string) (map[string]float64, error) {
+	prices := make(map[string]float64, len(tokenAddrs))
+	// callers maps each normalized address to the addresses passed for it
+	callers := make(map[string][]string, len(tokenAddrs))
+	var pending []string
+	for _, tokenAddr := range tokenAddrs {
+		normalized, err := address.Normalize(toOdosToken(tokenAddr))
+		if err != nil {
+			return nil, err
+		}
+		if c.prices != nil {
+			if price, ok := c.prices.get(priceCacheKey(chainID, normalized)); ok {
+				prices[tokenAddr] = price.Price
+				continue
+			}
+		}
+		if _, ok := callers[normalized]; !ok {
+			pending = append(pending, normalized)
+		}
+		callers[normalized] = append(callers[normalized], tokenAddr)
+	}
+
+	var chunks [][]string
+	for len(pending) > 0 {
+		n := min(len(pending), MaxPricesPerRequest)
+		chunks = append(chunks, pending[:n])
+		pending = pending[n:]
+	}
+
+	var mu sync.Mutex
+	var errs []error
+	sem := make(chan struct{}, _priceBatchConcurrency)
+	var wg sync.WaitGroup
+	for _, chunk := range chunks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			batch, err := c.getTokenPriceBatch(ctx, chainID, chunk)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs = append(errs, err)
+				return
+			}
+			for token, price := range batch.TokenPrices {
+				token = strings.ToLower(token)
+				if !(price > 0) {
+					continue
+				}
+				for _, tokenAddr := range callers[token] {
+					prices[tokenAddr] = price
+				}
+				if c.prices != nil {
+					c.prices.set(priceCacheKey(chainID, token), PriceResponse{CurrencyId: batch.CurrencyId, Price: price})
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(errs) > 0 {
+		return prices, fmt.Errorf("failed to get token prices: %w", errors.Join(errs...))
+	}
+	return prices, nil
+}
+
+// getTokenPriceBatch prices the normalized tokenAddrs in one request
+func (c *OdosClient) getTokenPriceBatch(ctx context.Context, chainID chains.ChainID, tokenAddrs []string) (*batchPriceResponse, error) {
+	query := url.Values{"token_addresses": tokenAddrs}
+	url := fmt.Sprintf("%s/pricing/token/%d?%s", c.baseURL, chainID, query.Encode())
+	c.logger.Debug().
+		Str("endpoint", EndpointTokenPrices).
+		Int64("chain", int64(chainID)).
+		Int("tokens", len(tokenAddrs)).
+		Msg("sending request")
+
+	request, err := c.newRequest("GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.do(EndpointTokenPrices, request.WithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token prices: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp.StatusCode, body)
+	}
+
+	var batch batchPriceResponse
+	if err := c.decodeStrict(resp.StatusCode, body, &batch); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return &batch, nil
+}
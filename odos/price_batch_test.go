@@ -0,0 +1,178 @@
+package odos
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGetTokenPricesCtx(t *testing.T) {
+	tokens := []string{DAI, sUSDe, "not-an-address", wstETH, ezETH}
+	server, client := NewTestServer(TestHandlers{
+		Price: func(w http.ResponseWriter, r *http.Request) {
+			token := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+			fmt.Fprintf(w, `{"currencyId":"USD","price":%d}`, len(token))
+		},
+	})
+	defer server.Close()
+
+	prices, errs := client.GetTokenPricesCtx(context.Background(), chainId, tokens, 2)
+	for i, token := range tokens {
+		if i == 2 {
+			if !errors.Is(errs[i], ErrInvalidAddress) || prices[i] != nil {
+				t.Errorf("GetTokenPricesCtx()[%d] = %v, %v, want %v", i, prices[i], errs[i], ErrInvalidAddress)
+			}
+			continue
+		}
+		if errs[i] != nil || prices[i].Price != float64(len(token)) {
+			t.Errorf("GetTokenPricesCtx()[%d] = %+v, %v", i, prices[i], errs[i])
+		}
+	}
+}
+
+func TestGetTokenPricesCtx_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var requests int32
+	server, client := NewTestServer(TestHandlers{
+		Price: func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&requests, 1) == 2 {
+				cancel()
+			}
+			w.Write([]byte(`{"currencyId":"USD","price":1}`))
+		},
+	})
+	defer server.Close()
+
+	tokens := []string{DAI, sUSDe, wstETH, ezETH, DAI, sUSDe}
+	prices, errs := client.GetTokenPricesCtx(ctx, chainId, tokens, 1)
+
+	if prices[0] == nil || errs[0] != nil {
+		t.Errorf("GetTokenPricesCtx()[0] = %v, %v, want the price priced before cancellation", prices[0], errs[0])
+	}
+	if !errors.Is(errs[len(tokens)-1], context.Canceled) || prices[len(tokens)-1] != nil {
+		t.Errorf("GetTokenPricesCtx()[last] = %v, %v, want %v", prices[len(tokens)-1], errs[len(tokens)-1], context.Canceled)
+	}
+	if n := atomic.LoadInt32(&requests); n > 3 {
+		t.Errorf("sent %d requests after cancellation", n)
+	}
+}
+
+func TestGetTokenPricesCtx_RateLimited(t *testing.T) {
+	server, client := NewTestServer(TestHandlers{}, WithRateLimit(0.001, 1))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	prices, errs := client.GetTokenPricesCtx(ctx, chainId, []string{DAI, sUSDe}, 2)
+	priced, limited := 0, 0
+	for i := range prices {
+		switch {
+		case errs[i] == nil && prices[i] != nil:
+			priced++
+		case errors.Is(errs[i], context.DeadlineExceeded):
+			limited++
+		}
+	}
+	if priced != 1 || limited != 1 {
+		t.Errorf("priced = %d, waited past the deadline = %d, want 1 and 1: %v", priced, limited, errs)
+	}
+}
+
+func TestWithRateLimit_NonPositive(t *testing.T) {
+	server, client := NewTestServer(TestHandlers{}, WithRateLimit(0, 1))
+	defer server.Close()
+
+	if client.limiter != nil {
+		t.Fatalf("WithRateLimit(0, 1) set a limiter")
+	}
+	_, errs := client.GetTokenPricesCtx(context.Background(), chainId, []string{DAI, sUSDe}, 2)
+	for i, err := range errs {
+		if err != nil {
+			t.Errorf("GetTokenPricesCtx() error %d = %v", i, err)
+		}
+	}
+}
+
+func TestGetTokenPrices(t *testing.T) {
+	tokens := []string{DAI}
+	for i := 1; i < 120; i++ {
+		tokens = append(tokens, fmt.Sprintf("0x%040x", i))
+	}
+
+	var requests int32
+	server, client := NewTestServer(TestHandlers{
+		Price: func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&requests, 1)
+			chunk := r.URL.Query()["token_addresses"]
+			if r.URL.Path != "/pricing/token/1" || len(chunk) > MaxPricesPerRequest {
+				t.Errorf("request %s with %d tokens", r.URL.Path, len(chunk))
+			}
+			prices := make(map[string]float64)
+			for _, token := range chunk {
+				if token != fmt.Sprintf("0x%040x", 7) {
+					prices[strings.ToUpper(token[:2])+token[2:]] = 2
+				}
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"currencyId": "USD", "tokenPrices": prices})
+		},
+	})
+	defer server.Close()
+
+	prices, err := client.GetTokenPrices(chainId, tokens)
+	if err != nil {
+		t.Fatalf("GetTokenPrices() error = %v", err)
+	}
+	if n := atomic.LoadInt32(&requests); n != 3 {
+		t.Errorf("sent %d requests, want 3", n)
+	}
+	if len(prices) != len(tokens)-1 || prices[DAI] != 2 {
+		t.Errorf("GetTokenPrices() priced %d tokens, DAI = %v", len(prices), prices[DAI])
+	}
+	if _, ok := prices[fmt.Sprintf("0x%040x", 7)]; ok {
+		t.Error("GetTokenPrices() priced a token missing from the response")
+	}
+}
+
+func TestGetTokenPrices_PartialFailure(t *testing.T) {
+	tokens := make([]string, MaxPricesPerRequest+1)
+	for i := range tokens {
+		tokens[i] = fmt.Sprintf("0x%040x", i+1)
+	}
+
+	server, client := NewTestServer(TestHandlers{
+		Price: func(w http.ResponseWriter, r *http.Request) {
+			chunk := r.URL.Query()["token_addresses"]
+			if len(chunk) == 1 {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			prices := make(map[string]float64)
+			for _, token := range chunk {
+				prices[token] = 1
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"currencyId": "USD", "tokenPrices": prices})
+		},
+	})
+	defer server.Close()
+
+	prices, err := client.GetTokenPrices(chainId, tokens)
+	if err == nil {
+		t.Error("GetTokenPrices() error = nil, want the failed chunk")
+	}
+	if len(prices) != MaxPricesPerRequest {
+		t.Errorf("GetTokenPrices() priced %d tokens, want %d", len(prices), MaxPricesPerRequest)
+	}
+
+	if _, err := client.GetTokenPrices(chainId, []string{"not-an-address"}); !errors.Is(err, ErrInvalidAddress) {
+		t.Errorf("GetTokenPrices() error = %v, want ErrInvalidAddress", err)
+	}
+}
@@ -0,0 +1,44 @@
+package odos
+
+import (
+	"strings"
+	"time"
+
+	"github.com/ThreeAndTwo/dex-swap-api-helper/chains"
+)
+
+// _priceCacheMaxEntries bounds the number of prices kept by the price cache
+const _priceCacheMaxEntries = 1024
+
+// priceCache memoizes token prices keyed by chain and token address
+type priceCache = ttlCache[PriceResponse]
+
+func newPriceCache(ttl time.Duration, maxEntries int) *priceCache {
+	return newTTLCache[PriceResponse](ttl, maxEntries)
+}
+
+func priceCacheKey(chainID chains.ChainID, tokenAddr string) string {
+	return chainID.String() + "/" + strings.ToLower(tokenAddr)
+}
+
+// WithPriceCache serves GetTokenPrice results from memory for ttl. The cache
+// is shared by concurrent callers and bounded in size.
+func (c *OdosClient) WithPriceCache(ttl time.Duration) *OdosClient {
+	c.prices = newPriceCache(ttl, _priceCacheMaxEntries)
+	return c
+}
+
+// InvalidatePrice drops the cached price of a token so the next
+// GetTokenPrice fetches it again
+func (c *OdosClient) InvalidatePrice(chainID chains.ChainID, tokenAddr string) {
+	if c.prices != nil {
+		c.prices.delete(priceCacheKey(chainID, tokenAddr))
+	}
+}
+
+// ClearPriceCache drops every cached price
+func (c *OdosClient) ClearPriceCache() {
+	if c.prices != nil {
+		c.prices.clear()
+	}
+}
@@ -0,0 +1,101 @@
+package odos
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGetTokenPrice_Cache(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Write([]byte(`{"currencyId":"USD","price":1.0001}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL).WithPriceCache(time.Minute)
+
+	for _, addr := range []string{DAI, strings.ToLower(DAI)} {
+		got, err := client.GetTokenPrice(chainId, addr)
+		if err != nil {
+			t.Fatalf("GetTokenPrice() error = %v", err)
+		}
+		if got.Price != 1.0001 {
+			t.Errorf("GetTokenPrice() = %v", got.Price)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("price fetched %d times, want 1", calls)
+	}
+
+	client.InvalidatePrice(chainId, DAI)
+	if _, err := client.GetTokenPrice(chainId, DAI); err != nil {
+		t.Fatalf("GetTokenPrice() error = %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("price fetched %d times after invalidation, want 2", calls)
+	}
+}
+
+func TestPriceCache_Bounded(t *testing.T) {
+	cache := newPriceCache(time.Minute, 3)
+	for i := 0; i < 10; i++ {
+		cache.set(fmt.Sprintf("1/0x%d", i), PriceResponse{Price: float64(i)})
+	}
+
+	if len(cache.entries) != 3 {
+		t.Errorf("cache holds %d entries, want 3", len(cache.entries))
+	}
+	if price, ok := cache.get("1/0x9"); !ok || price.Price != 9 {
+		t.Errorf("latest entry missing from cache")
+	}
+}
+
+func TestPriceCache_Expiry(t *testing.T) {
+	cache := newPriceCache(time.Millisecond, 3)
+	cache.set("1/0x0", PriceResponse{Price: 1})
+	time.Sleep(2 * time.Millisecond)
+
+	if _, ok := cache.get("1/0x0"); ok {
+		t.Errorf("expired entry served from cache")
+	}
+}
+
+func TestGetTokenPrice_NoPrice(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		body       string
+	}{
+		{name: "zero price", statusCode: http.StatusOK, body: `{"currencyId":"USD","price":0}`},
+		{name: "missing price", statusCode: http.StatusOK, body: `{"currencyId":"USD"}`},
+		{name: "not found", statusCode: http.StatusNotFound, body: `{"detail":"Token price not found"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls++
+				w.WriteHeader(tt.statusCode)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			client := NewClient(server.URL).WithPriceCache(time.Minute)
+			for i := 0; i < 2; i++ {
+				if _, err := client.GetTokenPrice(chainId, ezETH); !errors.Is(err, ErrNoPrice) {
+					t.Fatalf("GetTokenPrice() error = %v, want ErrNoPrice", err)
+				}
+			}
+			if calls != 2 {
+				t.Errorf("price fetched %d times, want 2", calls)
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package odos
+
+// QuotePrice quotes req for price discovery only, e.g. for dashboards with no
+// wallet connected. userAddr is omitted and the path visualization skipped,
+// the response only carries amounts and values: without a user Odos doesn't
+// reserve a path for assembly, so PathId is cleared. With WithQuoteCache
+// identical requests are served from memory.
+func (c *OdosClient) QuotePrice(req *QuoteRequest) (*QuoteResponse, error) {
+	var key string
+	if c.quotes != nil {
+		key = QuoteFingerprint(req)
+		if quoteResp, ok := c.quotes.get(key); ok {
+			return quoteResp.clone(), nil
+		}
+	}
+
+	priceReq := *req
+	priceReq.UserAddr = ""
+	priceReq.PathViz = false
+
+	quoteResp, err := c.Quote(&priceReq)
+	if err != nil {
+		return nil, err
+	}
+
+	quoteResp.PathId = ""
+	if c.quotes != nil {
+		c.quotes.set(key, *quoteResp.clone())
+	}
+	return quoteResp, nil
+}
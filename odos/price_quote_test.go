@@ -0,0 +1,96 @@
+package odos
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestQuotePrice(t *testing.T) {
+	var body map[string]interface{}
+	server, client := NewTestServer(TestHandlers{
+		Quote: func(w http.ResponseWriter, r *http.Request) {
+			json.NewDecoder(r.Body).Decode(&body)
+			w.Write([]byte(cannedQuoteResponse))
+		},
+	})
+	defer server.Close()
+
+	req := NewQuoteRequest(1, nil, nil).AddInput(DAI, "1000000000000000000").SetSingleOutput(sUSDe).WithUserAddr(testUserAddr)
+	req.PathViz = true
+
+	quoteResp, err := client.QuotePrice(req)
+	if err != nil {
+		t.Fatalf("QuotePrice() error = %v", err)
+	}
+
+	if _, ok := body["userAddr"]; ok {
+		t.Errorf("userAddr sent in price-only mode: %v", body["userAddr"])
+	}
+	if body["pathViz"] != false {
+		t.Errorf("pathViz = %v, want false", body["pathViz"])
+	}
+	if quoteResp.PathId != "" {
+		t.Errorf("PathId = %s, want none", quoteResp.PathId)
+	}
+	if quoteResp.OutAmounts[0] != "874518826958614826" {
+		t.Errorf("OutAmounts = %v", quoteResp.OutAmounts)
+	}
+	if req.UserAddr != testUserAddr || !req.PathViz {
+		t.Errorf("QuotePrice() modified the caller's request")
+	}
+}
+
+func TestQuotePrice_Cache(t *testing.T) {
+	var calls int
+	server, client := NewTestServer(TestHandlers{
+		Quote: func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			w.Write([]byte(cannedQuoteResponse))
+		},
+	}, WithQuoteCache(time.Minute))
+	defer server.Close()
+
+	req := NewQuoteRequest(1, nil, nil).AddInput(DAI, "1000000000000000000").SetSingleOutput(sUSDe).WithGasPrice(6)
+	first, err := client.QuotePrice(req)
+	if err != nil {
+		t.Fatalf("QuotePrice() error = %v", err)
+	}
+	first.OutAmounts[0] = "0"
+
+	same := NewQuoteRequest(1, nil, nil).AddInput(strings.ToLower(DAI), "1000000000000000000").SetSingleOutput(sUSDe).
+		WithGasPrice(7).WithUserAddr(testUserAddr)
+	cached, err := client.QuotePrice(same)
+	if err != nil {
+		t.Fatalf("QuotePrice() error = %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("quoted %d times, want 1", calls)
+	}
+	if cached.OutAmounts[0] != "874518826958614826" || cached.PathId != "" {
+		t.Errorf("cached quote = %+v", cached)
+	}
+
+	if _, err := client.QuotePrice(req.WithSlippageLimitPercent(1)); err != nil {
+		t.Fatalf("QuotePrice() error = %v", err)
+	}
+	if _, err := client.Quote(same); err != nil {
+		t.Fatalf("Quote() error = %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("quoted %d times, want 3: other requests and Quote aren't cached", calls)
+	}
+}
+
+func TestQuoteFingerprint(t *testing.T) {
+	req := NewQuoteRequest(1, nil, nil).AddInput(NativeToken, "1000").SetSingleOutput(sUSDe)
+	same := NewQuoteRequest(1, nil, nil).AddInput(_odosNativeToken, "1000").SetSingleOutput(strings.ToLower(sUSDe)).WithGasPrice(9)
+	if QuoteFingerprint(req) != QuoteFingerprint(same) {
+		t.Error("QuoteFingerprint() differs for the same quote")
+	}
+	if QuoteFingerprint(req) == QuoteFingerprint(NewQuoteRequest(1, nil, nil).AddInput(NativeToken, "1001").SetSingleOutput(sUSDe)) {
+		t.Error("QuoteFingerprint() equal for different amounts")
+	}
+}
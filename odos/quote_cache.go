@@ -0,0 +1,67 @@
+package odos
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"slices"
+	"strings"
+	"time"
+)
+
+// _quoteCacheMaxEntries bounds the number of quotes kept by the quote cache
+const _quoteCacheMaxEntries = 256
+
+// WithQuoteCache serves identical QuotePrice requests from memory for ttl,
+// e.g. a few seconds for dashboards polling the same pairs. Only QuotePrice
+// is cached: its quotes carry no PathId, so a cached quote can't be
+// assembled after its path expired. Requests are identified by
+// QuoteFingerprint.
+func WithQuoteCache(ttl time.Duration) Option {
+	return func(c *OdosClient) {
+		c.quotes = newTTLCache[QuoteResponse](ttl, _quoteCacheMaxEntries)
+	}
+}
+
+// ClearQuoteCache drops every cached quote
+func (c *OdosClient) ClearQuoteCache() {
+	if c.quotes != nil {
+		c.quotes.clear()
+	}
+}
+
+// QuoteFingerprint returns a hash identifying the quote req asks for.
+// Token addresses are compared case-insensitively and with the native token
+// sentinels unified, while the fields that don't change the price quoted
+// are left out: UserAddr, PathViz and GasPrice, which varies from one block
+// to the next.
+func QuoteFingerprint(req *QuoteRequest) string {
+	normalized := *req.withOdosTokens()
+	normalized.UserAddr = ""
+	normalized.PathViz = false
+	normalized.GasPrice = 0
+
+	normalized.InputTokens = slices.Clone(normalized.InputTokens)
+	for i := range normalized.InputTokens {
+		normalized.InputTokens[i].TokenAddress = strings.ToLower(normalized.InputTokens[i].TokenAddress)
+	}
+	normalized.OutputTokens = slices.Clone(normalized.OutputTokens)
+	for i := range normalized.OutputTokens {
+		normalized.OutputTokens[i].TokenAddress = strings.ToLower(normalized.OutputTokens[i].TokenAddress)
+	}
+
+	data, _ := json.Marshal(normalized)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// clone returns a copy of the quote not sharing its slices
+func (q QuoteResponse) clone() *QuoteResponse {
+	q.InTokens = slices.Clone(q.InTokens)
+	q.OutTokens = slices.Clone(q.OutTokens)
+	q.InAmounts = slices.Clone(q.InAmounts)
+	q.OutAmounts = slices.Clone(q.OutAmounts)
+	q.InValues = slices.Clone(q.InValues)
+	q.OutValues = slices.Clone(q.OutValues)
+	return &q
+}
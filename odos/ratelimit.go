@@ -0,0 +1,15 @@
+package odos
+
+import (
+	"github.com/ThreeAndTwo/dex-swap-api-helper/internal/ratelimit"
+)
+
+// WithRateLimit limits the client to requestsPerSecond on average, letting
+// bursts of up to burst requests through. Requests wait for their turn until
+// their context is done. A requestsPerSecond that isn't positive disables
+// the limit.
+func WithRateLimit(requestsPerSecond float64, burst int) Option {
+	return func(c *OdosClient) {
+		c.limiter = ratelimit.New(requestsPerSecond, burst)
+	}
+}
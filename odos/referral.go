@@ -0,0 +1,69 @@
+package odos
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// ReferralInfo represents the registration of a referral code
+type ReferralInfo struct {
+	Code         int     `json:"code"`
+	Description  string  `json:"description"`
+	FeeRecipient string  `json:"feeRecipient"`
+	Fee          float64 `json:"fee"` // percent of the swap output
+}
+
+// WithReferralCode sets the referral code sent with quotes that leave
+// ReferralCode at 0, so partners set it once instead of on every request.
+// A code set on the request takes precedence.
+func WithReferralCode(code int) Option {
+	return func(c *OdosClient) {
+		c.referral = code
+	}
+}
+
+// GetReferralInfo fetches the registration of a referral code, letting
+// partners confirm the code and its fee split before going live
+// /referral-code/{code}
+func (c *OdosClient) GetReferralInfo(code int) (*ReferralInfo, error) {
+	if err := ValidateReferralCode(code); err != nil {
+		return nil, err
+	}
+	if code == 0 {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidReferralCode, code)
+	}
+
+	url := fmt.Sprintf("%s/referral-code/%d", c.baseURL, code)
+
+	request, err := c.newRequest("GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.do(EndpointReferral, request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get referral info: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("status code %d, failed to read error response: %w", resp.StatusCode, err)
+		}
+		return nil, newAPIError(resp.StatusCode, body)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	var info ReferralInfo
+	if err := c.decodeBody(resp.StatusCode, body, &info); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &info, nil
+}
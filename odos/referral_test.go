@@ -0,0 +1,98 @@
+package odos
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestValidateReferralCode(t *testing.T) {
+	tests := []struct {
+		code  int64
+		valid bool
+	}{
+		{0, true},
+		{1, true},
+		{math.MaxInt32, true},
+		{math.MaxUint32, true},
+		{-1, false},
+		{math.MaxUint32 + 1, false},
+	}
+
+	for _, tt := range tests {
+		if int64(int(tt.code)) != tt.code {
+			continue // doesn't fit int on 32-bit platforms
+		}
+		err := ValidateReferralCode(int(tt.code))
+		if tt.valid && err != nil {
+			t.Errorf("ValidateReferralCode(%d) error = %v", tt.code, err)
+		}
+		if !tt.valid && !errors.Is(err, ErrInvalidReferralCode) {
+			t.Errorf("ValidateReferralCode(%d) error = %v, want ErrInvalidReferralCode", tt.code, err)
+		}
+	}
+}
+
+func TestQuote_InvalidReferralCode(t *testing.T) {
+	client := NewClient("http://127.0.0.1:1")
+	req := NewQuoteRequest(1, nil, nil).AddInput(DAI, "1000").SetSingleOutput(sUSDe).WithReferralCode(-12)
+	if _, err := client.Quote(req); !errors.Is(err, ErrInvalidReferralCode) {
+		t.Fatalf("Quote() error = %v, want ErrInvalidReferralCode", err)
+	}
+}
+
+func TestGetReferralInfo(t *testing.T) {
+	var path string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		w.Write([]byte(`{"code":2147483647,"description":"partner","feeRecipient":"0x163A5EC5e9C32238d075E2D829fE9fA87451e3b7","fee":0.1}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	info, err := client.GetReferralInfo(2147483647)
+	if err != nil {
+		t.Fatalf("GetReferralInfo() error = %v", err)
+	}
+	if path != "/referral-code/2147483647" {
+		t.Errorf("path = %s", path)
+	}
+	if info.Fee != 0.1 || info.FeeRecipient != testUserAddr {
+		t.Errorf("GetReferralInfo() = %+v", info)
+	}
+
+	if _, err := client.GetReferralInfo(0); !errors.Is(err, ErrInvalidReferralCode) {
+		t.Errorf("GetReferralInfo(0) error = %v, want ErrInvalidReferralCode", err)
+	}
+}
+
+func TestQuote_ClientReferralCode(t *testing.T) {
+	var codes []int
+	server, client := NewTestServer(TestHandlers{
+		Quote: func(w http.ResponseWriter, r *http.Request) {
+			var req QuoteRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			codes = append(codes, req.ReferralCode)
+			w.Write([]byte(cannedQuoteResponse))
+		},
+	}, WithReferralCode(2147483647))
+	defer server.Close()
+
+	req := NewQuoteRequest(1, nil, nil).AddInput(DAI, "1000").SetSingleOutput(sUSDe)
+	if _, err := client.Quote(req); err != nil {
+		t.Fatalf("Quote() error = %v", err)
+	}
+	if req.ReferralCode != 0 {
+		t.Errorf("request mutated, ReferralCode = %d", req.ReferralCode)
+	}
+	if _, err := client.Quote(req.WithReferralCode(7)); err != nil {
+		t.Fatalf("Quote() error = %v", err)
+	}
+
+	if len(codes) != 2 || codes[0] != 2147483647 || codes[1] != 7 {
+		t.Errorf("referral codes sent = %v, want [2147483647 7]", codes)
+	}
+}
@@ -0,0 +1,34 @@
+package odos
+
+import "github.com/ThreeAndTwo/dex-swap-api-helper/chains"
+
+// GetTokenPriceIn returns the price of tokenAddr quoted in quoteTokenAddr,
+// the ratio of their USD prices. CurrencyId of the response is the quote
+// token address. It fails with ErrNoMarketPrice when either token has no
+// market price.
+func (c *OdosClient) GetTokenPriceIn(chainID chains.ChainID, tokenAddr, quoteTokenAddr string) (*PriceResponse, error) {
+	base, err := c.marketPrice(chainID, tokenAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	quote, err := c.marketPrice(chainID, quoteTokenAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PriceResponse{
+		CurrencyId: quoteTokenAddr,
+		Price:      base / quote,
+	}, nil
+}
+
+// marketPrice returns the USD price of tokenAddr, failing with
+// ErrNoMarketPrice when Odos doesn't price it
+func (c *OdosClient) marketPrice(chainID chains.ChainID, tokenAddr string) (float64, error) {
+	priceResp, err := c.GetTokenPrice(chainID, tokenAddr)
+	if err != nil {
+		return 0, err
+	}
+	return priceResp.Price, nil
+}
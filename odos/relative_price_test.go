@@ -0,0 +1,39 @@
+package odos
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestGetTokenPriceIn(t *testing.T) {
+	prices := map[string]string{
+		strings.ToLower(wstETH): `{"currencyId":"USD","price":4000}`,
+		strings.ToLower(DAI):    `{"currencyId":"USD","price":1}`,
+		strings.ToLower(ezETH):  `{"currencyId":"USD","price":null}`,
+	}
+	server, client := NewTestServer(TestHandlers{
+		Price: func(w http.ResponseWriter, r *http.Request) {
+			parts := strings.Split(r.URL.Path, "/")
+			fmt.Fprint(w, prices[parts[len(parts)-1]])
+		},
+	})
+	defer server.Close()
+
+	got, err := client.GetTokenPriceIn(chainId, DAI, wstETH)
+	if err != nil {
+		t.Fatalf("GetTokenPriceIn() error = %v", err)
+	}
+	if got.Price != 0.00025 || got.CurrencyId != wstETH {
+		t.Errorf("GetTokenPriceIn() = %+v, want 0.00025 %s", got, wstETH)
+	}
+
+	if _, err := client.GetTokenPriceIn(chainId, ezETH, DAI); !errors.Is(err, ErrNoMarketPrice) {
+		t.Errorf("GetTokenPriceIn() unpriced base error = %v, want ErrNoMarketPrice", err)
+	}
+	if _, err := client.GetTokenPriceIn(chainId, DAI, ezETH); !errors.Is(err, ErrNoMarketPrice) {
+		t.Errorf("GetTokenPriceIn() unpriced quote error = %v, want ErrNoMarketPrice", err)
+	}
+}
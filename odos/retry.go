@@ -0,0 +1,53 @@
+package odos
+
+import (
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/ThreeAndTwo/dex-swap-api-helper/internal/retry"
+)
+
+// WithRetry retries requests rejected with 429 up to maxRetries times. Each
+// retry waits as long as the Retry-After header of the response asks, in
+// seconds or as a date, or baseDelay doubled on every attempt without it. A
+// wait longer than maxDelay returns the rate limited response instead.
+func WithRetry(maxRetries int, baseDelay, maxDelay time.Duration) Option {
+	return func(c *OdosClient) {
+		c.retry = &retry.Policy{MaxRetries: maxRetries, BaseDelay: baseDelay, MaxDelay: maxDelay}
+	}
+}
+
+// send sends req, retrying it while rate limited as configured by WithRetry
+func (c *OdosClient) send(req *http.Request) (*http.Response, error) {
+	resp, err := c.sender().Do(req)
+	if c.retry == nil {
+		return resp, err
+	}
+
+	for attempt := 0; attempt < c.retry.MaxRetries && err == nil && retry.Retryable(resp); attempt++ {
+		delay, ok := c.retry.Delay(attempt, resp, time.Now())
+		if !ok {
+			break
+		}
+		retryReq, ok := retry.Rewind(req)
+		if !ok {
+			break
+		}
+
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+
+		c.logger.Warn().
+			Str("url", req.URL.Path).
+			Int("attempt", attempt+1).
+			Dur("delay", delay).
+			Msg("rate limited, retrying")
+		if err := retry.Sleep(req.Context(), delay); err != nil {
+			return nil, err
+		}
+
+		resp, err = c.sender().Do(retryReq)
+	}
+	return resp, err
+}
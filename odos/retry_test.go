@@ -0,0 +1,63 @@
+package odos
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestWithRetry(t *testing.T) {
+	var attempts int
+	var bodies []QuoteRequest
+	server, client := NewTestServer(TestHandlers{
+		Quote: func(w http.ResponseWriter, r *http.Request) {
+			attempts++
+			var req QuoteRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			bodies = append(bodies, req)
+
+			if attempts < 3 {
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(http.StatusTooManyRequests)
+				w.Write([]byte(`{"detail":"Too many requests","errorCode":4290}`))
+				return
+			}
+			w.Write([]byte(cannedQuoteResponse))
+		},
+	}, WithRetry(3, time.Millisecond, time.Second))
+	defer server.Close()
+
+	if _, err := client.Quote(NewQuoteRequest(1, nil, nil).AddInput(DAI, "1000")); err != nil {
+		t.Fatalf("Quote() error = %v", err)
+	}
+	if attempts != 3 {
+		t.Errorf("attempts = %d, want 3", attempts)
+	}
+	for i, body := range bodies {
+		if len(body.InputTokens) != 1 || body.InputTokens[0].Amount != "1000" {
+			t.Errorf("attempt %d body = %+v, want the replayed request", i, body)
+		}
+	}
+}
+
+func TestWithRetry_RetryAfterTooLong(t *testing.T) {
+	var attempts int
+	server, client := NewTestServer(TestHandlers{
+		Quote: func(w http.ResponseWriter, r *http.Request) {
+			attempts++
+			w.Header().Set("Retry-After", "120")
+			w.WriteHeader(http.StatusTooManyRequests)
+		},
+	}, WithRetry(3, time.Millisecond, time.Second))
+	defer server.Close()
+
+	start := time.Now()
+	if _, err := client.Quote(NewQuoteRequest(1, nil, nil)); !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("Quote() error = %v, want %v", err, ErrRateLimited)
+	}
+	if attempts != 1 || time.Since(start) > 500*time.Millisecond {
+		t.Errorf("attempts = %d in %v, want a single attempt without waiting", attempts, time.Since(start))
+	}
+}
@@ -0,0 +1,137 @@
+package odos
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ThreeAndTwo/dex-swap-api-helper/internal/address"
+)
+
+var (
+	// ErrNoRFQSources is returned by QuoteRFQ when no RFQ source is given
+	ErrNoRFQSources = errors.New("odos: no RFQ liquidity source given")
+	// ErrNoRFQRoute is returned by QuoteRFQ for a quote whose path goes
+	// through none of the RFQ sources
+	ErrNoRFQRoute = errors.New("odos: quote doesn't route through an RFQ source")
+)
+
+// QuoteRFQ quotes req using only the given RFQ (market maker) liquidity
+// sources, as listed by Odos liquidity sources, e.g. "Hashflow" or "Bebop".
+//
+// Odos serves RFQ fills through the regular SOR endpoints: the firm price is
+// held by the maker for the path lifetime. The quote is requested with its
+// path visualization, a path going through none of sources fails with
+// ErrNoRFQRoute. Use AssembleRFQ to quote and assemble in one step, RFQ
+// quotes expire sooner than AMM ones.
+func (c *OdosClient) QuoteRFQ(req *QuoteRequest, sources []string) (*QuoteResponse, error) {
+	if len(sources) == 0 {
+		return nil, ErrNoRFQSources
+	}
+
+	rfqReq := *req
+	rfqReq.DisableRFQs = false
+	rfqReq.SourceWhitelist = sources
+	rfqReq.PathViz = true
+
+	quoteResp, err := c.Quote(&rfqReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get RFQ quote: %w", err)
+	}
+	if !quoteResp.routesThrough(sources) {
+		return nil, fmt.Errorf("%w: %s", ErrNoRFQRoute, strings.Join(sources, ", "))
+	}
+
+	return quoteResp, nil
+}
+
+// routesThrough reports whether a link of the path visualization is
+// labelled with one of sources, ignoring case
+func (q *QuoteResponse) routesThrough(sources []string) bool {
+	for _, link := range q.PathViz.Links {
+		for _, source := range sources {
+			if strings.EqualFold(link.Label, source) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// RFQFill is an RFQ quote with the transaction assembled from it
+type RFQFill struct {
+	Quote    *QuoteResponse
+	Assemble *AssembleResponse
+	// Sources are the RFQ liquidity sources the quote was restricted to
+	Sources []string
+}
+
+// RFQOrder holds the transaction filling an RFQ quote: the router, calldata
+// and value of the assembled transaction, with the quoted output amounts.
+// The maker order and its signature are embedded in the calldata by Odos,
+// they aren't decoded. Sending Calldata with Value to Router fills the order
+// at the quoted price until ExpiresAt.
+type RFQOrder struct {
+	Router   string
+	Calldata []byte
+	// Value is the wei sent with the fill, 0 unless the input is native
+	Value *big.Int
+	// AmountsOut are the raw output amounts the maker committed to
+	AmountsOut []string
+	// ExpiresAt is when the path id of the quote expires, the maker
+	// doesn't hold the price longer
+	ExpiresAt time.Time
+}
+
+// AssembleRFQ quotes req with QuoteRFQ and assembles the path for userAddr
+// right away, leaving the short lived RFQ price no time to expire in
+// between. The fill transaction is read from the result with Order.
+func (c *OdosClient) AssembleRFQ(userAddr string, req *QuoteRequest, sources []string) (*RFQFill, error) {
+	quoteResp, err := c.QuoteRFQ(req, sources)
+	if err != nil {
+		return nil, err
+	}
+	if quoteResp.PathId == "" {
+		return nil, fmt.Errorf("failed to get RFQ quote: %w", ErrEmptyPathId)
+	}
+
+	assembleResp, err := c.AssembleQuote(userAddr, quoteResp, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to assemble RFQ quote %s: %w", quoteResp.PathId, err)
+	}
+
+	return &RFQFill{
+		Quote:    quoteResp,
+		Assemble: assembleResp,
+		Sources:  sources,
+	}, nil
+}
+
+// Order returns the transaction filling the RFQ quote, failing when the
+// assembled transaction doesn't carry a valid router and calldata
+func (f *RFQFill) Order() (*RFQOrder, error) {
+	tx := f.Assemble.Transaction
+	router, err := address.Normalize(tx.To)
+	if err != nil {
+		return nil, fmt.Errorf("invalid RFQ order router: %w", err)
+	}
+	calldata, err := hex.DecodeString(strings.TrimPrefix(tx.Data, "0x"))
+	if err != nil || len(calldata) == 0 {
+		return nil, fmt.Errorf("invalid RFQ order calldata %q", tx.Data)
+	}
+	value, err := tx.ValueBig()
+	if err != nil {
+		return nil, err
+	}
+
+	return &RFQOrder{
+		Router:     router,
+		Calldata:   calldata,
+		Value:      value,
+		AmountsOut: f.Quote.OutAmounts,
+		ExpiresAt:  f.Quote.PathIdExpiresAt(),
+	}, nil
+}
@@ -0,0 +1,78 @@
+package odos
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestQuoteRFQ(t *testing.T) {
+	var got QuoteRequest
+	server, client := NewTestServer(TestHandlers{
+		Quote: func(w http.ResponseWriter, r *http.Request) {
+			json.NewDecoder(r.Body).Decode(&got)
+			w.Write([]byte(`{"pathId":"rfq","pathViz":{"links":[{"source":0,"target":1,"label":"Hashflow"}]}}`))
+		},
+	})
+	defer server.Close()
+
+	req := NewQuoteRequest(1, nil, nil).WithDisableRFQs(true)
+	if _, err := client.QuoteRFQ(req, nil); !errors.Is(err, ErrNoRFQSources) {
+		t.Fatalf("QuoteRFQ() error = %v, want ErrNoRFQSources", err)
+	}
+
+	if _, err := client.QuoteRFQ(req, []string{"Hashflow"}); err != nil {
+		t.Fatalf("QuoteRFQ() error = %v", err)
+	}
+	if got.DisableRFQs || !got.PathViz || len(got.SourceWhitelist) != 1 || got.SourceWhitelist[0] != "Hashflow" {
+		t.Errorf("quote request = %+v", got)
+	}
+	if !req.DisableRFQs || len(req.SourceWhitelist) != 0 || req.PathViz {
+		t.Errorf("QuoteRFQ() modified the caller's request")
+	}
+
+	if _, err := client.QuoteRFQ(req, []string{"Bebop"}); !errors.Is(err, ErrNoRFQRoute) {
+		t.Errorf("QuoteRFQ() through another source error = %v, want ErrNoRFQRoute", err)
+	}
+}
+
+func TestAssembleRFQ(t *testing.T) {
+	var quoted QuoteRequest
+	var assembled AssembleRequest
+	server, client := NewTestServer(TestHandlers{
+		Quote: func(w http.ResponseWriter, r *http.Request) {
+			json.NewDecoder(r.Body).Decode(&quoted)
+			w.Write([]byte(`{"pathId":"rfq","outAmounts":["874518826958614826"],"pathViz":{"links":[{"label":"Hashflow"}]}}`))
+		},
+		Assemble: func(w http.ResponseWriter, r *http.Request) {
+			json.NewDecoder(r.Body).Decode(&assembled)
+			w.Write([]byte(cannedAssembleResponse))
+		},
+	})
+	defer server.Close()
+
+	req := NewQuoteRequest(1, []InputToken{{TokenAddress: DAI, Amount: "1000"}}, nil).SetSingleOutput(sUSDe).WithUserAddr(testUserAddr)
+	fill, err := client.AssembleRFQ(testUserAddr, req, []string{"Hashflow"})
+	if err != nil {
+		t.Fatalf("AssembleRFQ() error = %v", err)
+	}
+	if len(quoted.SourceWhitelist) != 1 || assembled.PathId != "rfq" || assembled.Simulate {
+		t.Errorf("quoted %+v, assembled %+v", quoted, assembled)
+	}
+
+	order, err := fill.Order()
+	if err != nil {
+		t.Fatalf("Order() error = %v", err)
+	}
+	if order.Router != "0xcf5540fffcdc3d510b18bfca6d2b9987b0772559" || hex.EncodeToString(order.Calldata) != "83bd37f9" ||
+		order.Value.Sign() != 0 || len(order.AmountsOut) != 1 || order.ExpiresAt.IsZero() {
+		t.Errorf("Order() = %+v", order)
+	}
+
+	fill.Assemble.Transaction.Data = "0x"
+	if _, err := fill.Order(); err == nil {
+		t.Error("Order() with empty calldata error = nil")
+	}
+}
@@ -0,0 +1,38 @@
+package odos
+
+import (
+	"context"
+
+	"github.com/ThreeAndTwo/dex-swap-api-helper/internal/drain"
+)
+
+// ErrClientClosed is returned without sending the request once Shutdown or
+// Close was called
+var ErrClientClosed = drain.ErrClosed
+
+// Shutdown stops the client from sending new requests, failing them with
+// ErrClientClosed, and waits for the requests in flight to finish. When ctx
+// is done first, the requests still in flight are cancelled and ctx.Err()
+// is returned. Idle connections are closed either way, unless the HTTP
+// client was given with WithHTTPClient, as that would drop the pooled
+// connections of the other clients using it.
+func (c *OdosClient) Shutdown(ctx context.Context) error {
+	defer c.closeIdleConnections()
+	return c.drain.Shutdown(ctx)
+}
+
+// Close stops the client from sending new requests, cancels the requests in
+// flight and waits for them to return
+func (c *OdosClient) Close() error {
+	c.drain.Close()
+	c.closeIdleConnections()
+	return nil
+}
+
+// closeIdleConnections closes the idle connections of the HTTP client,
+// unless other clients use it too
+func (c *OdosClient) closeIdleConnections() {
+	if !c.sharedHTTP {
+		c.httpClient.CloseIdleConnections()
+	}
+}
@@ -0,0 +1,99 @@
+package odos
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestShutdown(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	server, client := NewTestServer(TestHandlers{
+		Price: func(w http.ResponseWriter, r *http.Request) {
+			close(started)
+			<-release
+			w.Write([]byte(cannedPriceResponse))
+		},
+	})
+	defer server.Close()
+
+	result := make(chan error)
+	go func() {
+		_, err := client.GetTokenPrice(chainId, DAI)
+		result <- err
+	}()
+	<-started
+
+	shutdown := make(chan error)
+	go func() { shutdown <- client.Shutdown(context.Background()) }()
+
+	// new calls are refused once Shutdown runs, while the price is in flight
+	var err error
+	for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); time.Sleep(time.Millisecond) {
+		if _, err = client.GetGasPrice(1); err != nil {
+			break
+		}
+	}
+	if !errors.Is(err, ErrClientClosed) {
+		t.Errorf("GetGasPrice() after Shutdown error = %v, want ErrClientClosed", err)
+	}
+
+	close(release)
+	if err := <-result; err != nil {
+		t.Errorf("in flight GetTokenPrice() error = %v", err)
+	}
+	if err := <-shutdown; err != nil {
+		t.Errorf("Shutdown() error = %v", err)
+	}
+}
+
+func TestShutdown_Deadline(t *testing.T) {
+	started := make(chan struct{})
+	server, client := NewTestServer(TestHandlers{
+		Price: func(w http.ResponseWriter, r *http.Request) {
+			close(started)
+			<-r.Context().Done()
+		},
+	})
+	defer server.Close()
+
+	result := make(chan error)
+	go func() {
+		_, err := client.GetTokenPrice(chainId, DAI)
+		result <- err
+	}()
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := client.Shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Shutdown() error = %v, want DeadlineExceeded", err)
+	}
+	if err := <-result; !errors.Is(err, context.Canceled) {
+		t.Errorf("in flight GetTokenPrice() error = %v, want Canceled", err)
+	}
+}
+
+// idleCloseCounter is a transport counting the CloseIdleConnections calls
+type idleCloseCounter struct {
+	http.RoundTripper
+	closes int
+}
+
+func (t *idleCloseCounter) CloseIdleConnections() {
+	t.closes++
+}
+
+func TestClose_SharedHTTPClient(t *testing.T) {
+	transport := &idleCloseCounter{RoundTripper: http.DefaultTransport}
+	client := NewClientWithOptions(WithHTTPClient(&http.Client{Transport: transport}))
+
+	client.Close()
+	client.Shutdown(context.Background())
+	if transport.closes != 0 {
+		t.Errorf("idle connections of the shared HTTP client closed %d times, want 0", transport.closes)
+	}
+}
@@ -0,0 +1,32 @@
+package odos
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// RequestSigner is called with every request and a copy of its body just
+// before the request is sent, e.g. to attach the HMAC signature header an
+// authenticating gateway requires. A retried request is signed again.
+type RequestSigner func(req *http.Request, body []byte) error
+
+// WithRequestSigner signs the requests with signer before they are sent. A
+// signer error fails the call without sending the request.
+func WithRequestSigner(signer RequestSigner) Option {
+	return func(c *OdosClient) {
+		c.signer = signer
+	}
+}
+
+// signingDoer signs requests before handing them to the next Doer
+type signingDoer struct {
+	next Doer
+	sign RequestSigner
+}
+
+func (d signingDoer) Do(req *http.Request) (*http.Response, error) {
+	if err := d.sign(req, requestBody(req)); err != nil {
+		return nil, fmt.Errorf("failed to sign request: %w", err)
+	}
+	return d.next.Do(req)
+}
@@ -0,0 +1,57 @@
+package odos
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestWithRequestSigner(t *testing.T) {
+	key := []byte("secret")
+	sign := func(body []byte) string {
+		mac := hmac.New(sha256.New, key)
+		mac.Write(body)
+		return hex.EncodeToString(mac.Sum(nil))
+	}
+
+	var got, want string
+	server, client := NewTestServer(TestHandlers{
+		Quote: func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			got, want = r.Header.Get("X-Signature"), sign(body)
+			w.Write([]byte(cannedQuoteResponse))
+		},
+	}, WithRequestSigner(func(req *http.Request, body []byte) error {
+		req.Header.Set("X-Signature", sign(body))
+		return nil
+	}))
+	defer server.Close()
+
+	req := NewQuoteRequest(1, []InputToken{{TokenAddress: DAI, Amount: "1000"}}, nil).SetSingleOutput(sUSDe).WithUserAddr(testUserAddr)
+	if _, err := client.Quote(req); err != nil {
+		t.Fatalf("Quote() error = %v", err)
+	}
+	if got == "" || got != want {
+		t.Errorf("X-Signature = %q, want %q", got, want)
+	}
+}
+
+func TestWithRequestSigner_Error(t *testing.T) {
+	errSign := errors.New("no key")
+	server, client := NewTestServer(TestHandlers{
+		Price: func(w http.ResponseWriter, r *http.Request) {
+			t.Error("request sent despite the signer error")
+		},
+	}, WithRequestSigner(func(req *http.Request, body []byte) error {
+		return errSign
+	}))
+	defer server.Close()
+
+	if _, err := client.GetTokenPrice(chainId, DAI); !errors.Is(err, errSign) {
+		t.Errorf("GetTokenPrice() error = %v, want the signer error", err)
+	}
+}
@@ -0,0 +1,140 @@
+package odos
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// SimulationOutcome classifies the result of an assemble simulation
+type SimulationOutcome int
+
+const (
+	SimulationSuccess SimulationOutcome = iota
+	SimulationReverted
+	SimulationOutOfGas
+)
+
+func (o SimulationOutcome) String() string {
+	switch o {
+	case SimulationSuccess:
+		return "success"
+	case SimulationReverted:
+		return "reverted"
+	case SimulationOutOfGas:
+		return "out of gas"
+	}
+	return fmt.Sprintf("SimulationOutcome(%d)", int(o))
+}
+
+var (
+	// ErrSimulationReverted matches every failed simulation that isn't out of gas
+	ErrSimulationReverted = errors.New("odos: simulation reverted")
+	// ErrSimulationOutOfGas matches simulations that ran out of gas
+	ErrSimulationOutOfGas = errors.New("odos: simulation ran out of gas")
+	// ErrSlippageExceeded matches reverts caused by the slippage limit
+	ErrSlippageExceeded = errors.New("odos: slippage limit exceeded")
+	// ErrInsufficientBalance matches reverts caused by a too low token balance
+	ErrInsufficientBalance = errors.New("odos: insufficient balance")
+	// ErrInsufficientAllowance matches reverts caused by a missing approval
+	ErrInsufficientAllowance = errors.New("odos: insufficient allowance")
+)
+
+// revertReasons maps known revert reason fragments to their sentinel error
+var revertReasons = []struct {
+	fragment string
+	err      error
+}{
+	{"slippage", ErrSlippageExceeded},
+	{"exceeds balance", ErrInsufficientBalance},
+	{"insufficient balance", ErrInsufficientBalance},
+	{"allowance", ErrInsufficientAllowance},
+}
+
+// SimulationError describes a failed simulation. It matches the outcome
+// sentinel and, when the reason is recognized, the reason sentinel with errors.Is
+type SimulationError struct {
+	Outcome SimulationOutcome
+	Reason  string
+	reason  error
+}
+
+func (e *SimulationError) Error() string {
+	return fmt.Sprintf("simulation %s: %s", e.Outcome, e.Reason)
+}
+
+func (e *SimulationError) Is(target error) bool {
+	switch target {
+	case ErrSimulationReverted:
+		return e.Outcome == SimulationReverted
+	case ErrSimulationOutOfGas:
+		return e.Outcome == SimulationOutOfGas
+	}
+	return e.reason != nil && e.reason == target
+}
+
+// Result classifies the simulation, returning a *SimulationError with the
+// decoded revert reason when it didn't succeed
+func (s Simulation) Result() (SimulationOutcome, error) {
+	if s.IsSuccess {
+		return SimulationSuccess, nil
+	}
+
+	reason := decodeRevertReason(s.SimulationError)
+	lower := strings.ToLower(reason)
+
+	simErr := &SimulationError{Outcome: SimulationReverted, Reason: reason}
+	if strings.Contains(lower, "out of gas") {
+		simErr.Outcome = SimulationOutOfGas
+	}
+	for _, r := range revertReasons {
+		if strings.Contains(lower, r.fragment) {
+			simErr.reason = r.err
+			break
+		}
+	}
+
+	return simErr.Outcome, simErr
+}
+
+// _errorStringSelector is the selector of the solidity Error(string) revert
+const _errorStringSelector = "08c379a0"
+
+// decodeRevertReason extracts the message of an ABI encoded Error(string)
+// revert, returning the input unchanged when it isn't one
+func decodeRevertReason(raw string) string {
+	idx := strings.Index(raw, "0x"+_errorStringSelector)
+	if idx < 0 {
+		return raw
+	}
+
+	data, err := hex.DecodeString(strings.TrimSpace(raw[idx+2+len(_errorStringSelector):]))
+	if err != nil || len(data) < 64 {
+		return raw
+	}
+
+	length := binary.BigEndian.Uint64(data[56:64])
+	if uint64(len(data)-64) < length {
+		return raw
+	}
+	return string(data[64 : 64+length])
+}
+
+// SimulateQuote assembles pathId with simulation enabled as a pre-trade
+// check and returns only the simulation, dropping the calldata. When the
+// simulation failed the *SimulationError of Result is returned along with
+// it, so errors.Is matches e.g. ErrSlippageExceeded.
+func (c *OdosClient) SimulateQuote(userAddr, pathId string) (*Simulation, error) {
+	assembleResp, err := c.Assemble(userAddr, pathId, true)
+	if err != nil {
+		return nil, err
+	}
+
+	simulation := assembleResp.Simulation
+	if _, err := simulation.Result(); err != nil {
+		return &simulation, err
+	}
+	return &simulation, nil
+}
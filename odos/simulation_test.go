@@ -0,0 +1,116 @@
+package odos
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+// encodeRevert ABI encodes msg as an Error(string) revert payload
+func encodeRevert(msg string) string {
+	data := make([]byte, 64+((len(msg)+31)/32)*32)
+	data[31] = 32
+	data[63] = byte(len(msg))
+	copy(data[64:], msg)
+	return "0x" + _errorStringSelector + hex.EncodeToString(data)
+}
+
+func TestSimulation_Result(t *testing.T) {
+	tests := []struct {
+		name        string
+		simulation  Simulation
+		wantOutcome SimulationOutcome
+		wantErrs    []error
+	}{
+		{
+			name:        "success",
+			simulation:  Simulation{IsSuccess: true},
+			wantOutcome: SimulationSuccess,
+		},
+		{
+			name:        "slippage revert",
+			simulation:  Simulation{SimulationError: "execution reverted: Slippage Limit Exceeded"},
+			wantOutcome: SimulationReverted,
+			wantErrs:    []error{ErrSimulationReverted, ErrSlippageExceeded},
+		},
+		{
+			name:        "encoded balance revert",
+			simulation:  Simulation{SimulationError: encodeRevert("ERC20: transfer amount exceeds balance")},
+			wantOutcome: SimulationReverted,
+			wantErrs:    []error{ErrSimulationReverted, ErrInsufficientBalance},
+		},
+		{
+			name:        "out of gas",
+			simulation:  Simulation{SimulationError: "out of gas"},
+			wantOutcome: SimulationOutOfGas,
+			wantErrs:    []error{ErrSimulationOutOfGas},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outcome, err := tt.simulation.Result()
+			if outcome != tt.wantOutcome {
+				t.Errorf("Result() outcome = %v, want %v", outcome, tt.wantOutcome)
+			}
+			if len(tt.wantErrs) == 0 && err != nil {
+				t.Errorf("Result() error = %v, want nil", err)
+			}
+			for _, want := range tt.wantErrs {
+				if !errors.Is(err, want) {
+					t.Errorf("Result() error = %v, want %v", err, want)
+				}
+			}
+		})
+	}
+}
+
+func TestDecodeRevertReason(t *testing.T) {
+	if got := decodeRevertReason(encodeRevert("STF")); got != "STF" {
+		t.Errorf("decodeRevertReason() = %q, want STF", got)
+	}
+	if got := decodeRevertReason("plain reason"); got != "plain reason" {
+		t.Errorf("decodeRevertReason() = %q", got)
+	}
+}
+
+func TestSimulateQuote(t *testing.T) {
+	var simulate bool
+	failing := false
+	server, client := NewTestServer(TestHandlers{
+		Assemble: func(w http.ResponseWriter, r *http.Request) {
+			var req AssembleRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			simulate = req.Simulate
+			if failing {
+				fmt.Fprintf(w, `{"simulation":{"isSuccess":false,"gasEstimate":90000,"simulationError":%q}}`, encodeRevert("Slippage Limit Exceeded"))
+				return
+			}
+			w.Write([]byte(cannedAssembleResponse))
+		},
+	})
+	defer server.Close()
+
+	simulation, err := client.SimulateQuote(testUserAddr, "path")
+	if err != nil {
+		t.Fatalf("SimulateQuote() error = %v", err)
+	}
+	if !simulate {
+		t.Error("SimulateQuote() assembled without simulation")
+	}
+	if !simulation.IsSuccess || simulation.GasEstimate != 235623 || len(simulation.AmountsOut) != 1 {
+		t.Errorf("SimulateQuote() = %+v", simulation)
+	}
+
+	failing = true
+	simulation, err = client.SimulateQuote(testUserAddr, "path")
+	if !errors.Is(err, ErrSlippageExceeded) {
+		t.Errorf("SimulateQuote() error = %v, want ErrSlippageExceeded", err)
+	}
+	if simulation == nil || simulation.GasEstimate != 90000 {
+		t.Errorf("SimulateQuote() = %+v, want the failed simulation", simulation)
+	}
+}
@@ -0,0 +1,53 @@
+package odos
+
+import "math"
+
+// SlippagePolicy derives a slippage limit from the price impact of a quote,
+// all values being percents like SlippageLimitPercent
+type SlippagePolicy struct {
+	// Floor is the lowest slippage suggested, used for low impact swaps
+	Floor float64
+	// Ceiling is the highest slippage suggested, capping the loss on high
+	// impact swaps
+	Ceiling float64
+	// Multiplier scales the price impact to leave room for the price moving
+	// between quote and execution
+	Multiplier float64
+}
+
+// DefaultSlippagePolicy is the policy used by SuggestSlippage
+var DefaultSlippagePolicy = SlippagePolicy{
+	Floor:      DefaultSlippageLimitPercent,
+	Ceiling:    5,
+	Multiplier: 1.5,
+}
+
+// SuggestSlippage returns the slippage percent suggested by
+// DefaultSlippagePolicy for a quote price impact in percent
+func SuggestSlippage(impact float64) float64 {
+	return DefaultSlippagePolicy.Suggest(impact)
+}
+
+// Suggest returns the slippage percent for a quote price impact in percent,
+// the scaled impact bounded by Floor and Ceiling
+func (p SlippagePolicy) Suggest(impact float64) float64 {
+	slippage := math.Abs(impact) * p.Multiplier
+	if slippage < p.Floor {
+		slippage = p.Floor
+	}
+	if p.Ceiling > 0 && slippage > p.Ceiling {
+		slippage = p.Ceiling
+	}
+	return slippage
+}
+
+// WithSuggestedSlippage sets the slippage limit suggested by policy for the
+// price impact of quote, typically before re-quoting a high impact swap. A
+// nil policy uses DefaultSlippagePolicy.
+func (r *QuoteRequest) WithSuggestedSlippage(quote *QuoteResponse, policy *SlippagePolicy) *QuoteRequest {
+	if policy == nil {
+		policy = &DefaultSlippagePolicy
+	}
+	r.SlippageLimitPercent = policy.Suggest(quote.PriceImpact)
+	return r
+}
@@ -0,0 +1,46 @@
+package odos
+
+import "testing"
+
+func TestSuggestSlippage(t *testing.T) {
+	tests := []struct {
+		impact float64
+		want   float64
+	}{
+		{0, DefaultSlippageLimitPercent},
+		{0.1, DefaultSlippageLimitPercent},
+		{1, 1.5},
+		{-2, 3},
+		{10, 5},
+	}
+
+	for _, tt := range tests {
+		if got := SuggestSlippage(tt.impact); got != tt.want {
+			t.Errorf("SuggestSlippage(%v) = %v, want %v", tt.impact, got, tt.want)
+		}
+	}
+}
+
+func TestSlippagePolicy_Suggest(t *testing.T) {
+	policy := SlippagePolicy{Floor: 0.5, Multiplier: 2}
+	if got := policy.Suggest(0.1); got != 0.5 {
+		t.Errorf("Suggest(0.1) = %v, want floor 0.5", got)
+	}
+	if got := policy.Suggest(20); got != 40 {
+		t.Errorf("Suggest(20) = %v, want 40 without a ceiling", got)
+	}
+}
+
+func TestQuoteRequest_WithSuggestedSlippage(t *testing.T) {
+	quote := &QuoteResponse{PriceImpact: 2}
+
+	req := NewQuoteRequest(1, nil, nil).WithSuggestedSlippage(quote, nil)
+	if req.SlippageLimitPercent != 3 {
+		t.Errorf("SlippageLimitPercent = %v, want 3", req.SlippageLimitPercent)
+	}
+
+	req.WithSuggestedSlippage(quote, &SlippagePolicy{Ceiling: 1, Multiplier: 1})
+	if req.SlippageLimitPercent != 1 {
+		t.Errorf("SlippageLimitPercent = %v, want 1", req.SlippageLimitPercent)
+	}
+}
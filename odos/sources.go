@@ -0,0 +1,94 @@
+package odos
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/ThreeAndTwo/dex-swap-api-helper/chains"
+)
+
+// GetLiquiditySources fetches the ids of the liquidity sources Odos routes
+// through on a chain, as used in source blacklists and whitelists
+// /info/liquidity-sources/{chainId}
+func (c *OdosClient) GetLiquiditySources(chainID chains.ChainID) ([]string, error) {
+	url := fmt.Sprintf("%s/info/liquidity-sources/%d", c.baseURL, chainID)
+
+	request, err := c.newRequest("GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.do(EndpointSources, request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get liquidity sources: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp.StatusCode, body)
+	}
+
+	var sources []string
+	if err := c.decodeBody(resp.StatusCode, body, &sources); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return sources, nil
+}
+
+// ValidateSources checks every source id is a liquidity source of the chain,
+// returning an error wrapping ErrUnknownSource that lists the unknown ones.
+// Odos ignores unknown ids, so a typo would silently exclude nothing.
+func (c *OdosClient) ValidateSources(chainID chains.ChainID, sources []string) error {
+	if len(sources) == 0 {
+		return nil
+	}
+
+	available, err := c.GetLiquiditySources(chainID)
+	if err != nil {
+		return err
+	}
+
+	known := make(map[string]bool, len(available))
+	folded := make(map[string]string, len(available))
+	for _, source := range available {
+		known[source] = true
+		folded[strings.ToLower(source)] = source
+	}
+
+	var unknown []string
+	for _, source := range sources {
+		if known[source] {
+			continue
+		}
+		if match, ok := folded[strings.ToLower(source)]; ok {
+			source = fmt.Sprintf("%q (did you mean %q?)", source, match)
+		} else {
+			source = fmt.Sprintf("%q", source)
+		}
+		unknown = append(unknown, source)
+	}
+
+	if len(unknown) > 0 {
+		return fmt.Errorf("%w on chain %d: %s", ErrUnknownSource, chainID, strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// BlacklistSources adds sources to the source blacklist of req after
+// checking they are liquidity sources of its chain, leaving req unchanged
+// when any of them is unknown
+func (c *OdosClient) BlacklistSources(req *QuoteRequest, sources ...string) error {
+	if err := c.ValidateSources(req.ChainId, sources); err != nil {
+		return err
+	}
+
+	req.SourceBlacklist = append(req.SourceBlacklist, sources...)
+	return nil
+}
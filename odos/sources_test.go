@@ -0,0 +1,56 @@
+package odos
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestGetLiquiditySources(t *testing.T) {
+	var path string
+	server, client := NewTestServer(TestHandlers{
+		Sources: func(w http.ResponseWriter, r *http.Request) {
+			path = r.URL.Path
+			w.Write([]byte(cannedSourcesResponse))
+		},
+	})
+	defer server.Close()
+
+	sources, err := client.GetLiquiditySources(8453)
+	if err != nil {
+		t.Fatalf("GetLiquiditySources() error = %v", err)
+	}
+	if path != "/info/liquidity-sources/8453" || len(sources) != 6 || sources[0] != "Uniswap V2" {
+		t.Errorf("GetLiquiditySources() = %v from %s", sources, path)
+	}
+}
+
+func TestBlacklistSources(t *testing.T) {
+	server, client := NewTestServer(TestHandlers{})
+	defer server.Close()
+
+	req := NewQuoteRequest(1, nil, nil)
+	if err := client.BlacklistSources(req, "Hashflow", "Maker PSM"); err != nil {
+		t.Fatalf("BlacklistSources() error = %v", err)
+	}
+	if strings.Join(req.SourceBlacklist, ",") != "Hashflow,Maker PSM" {
+		t.Errorf("SourceBlacklist = %v", req.SourceBlacklist)
+	}
+
+	err := client.BlacklistSources(req, "Uniswap V2", "uniswap v3", "Curve Stabel")
+	if !errors.Is(err, ErrUnknownSource) {
+		t.Fatalf("BlacklistSources() error = %v, want %v", err, ErrUnknownSource)
+	}
+	for _, want := range []string{`"uniswap v3" (did you mean "Uniswap V3"?)`, `"Curve Stabel"`} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("BlacklistSources() error = %q, want it to contain %s", err, want)
+		}
+	}
+	if strings.Contains(err.Error(), `"Uniswap V2"`) {
+		t.Errorf("BlacklistSources() error = %q reports a known source", err)
+	}
+	if len(req.SourceBlacklist) != 2 {
+		t.Errorf("SourceBlacklist = %v, changed by a failed call", req.SourceBlacklist)
+	}
+}
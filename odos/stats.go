@@ -0,0 +1,26 @@
+package odos
+
+import (
+	"net/http"
+
+	"github.com/ThreeAndTwo/dex-swap-api-helper/internal/stats"
+)
+
+// EndpointStats holds the request count, error count and moving average
+// latency observed for an endpoint
+type EndpointStats = stats.Endpoint
+
+// Stats returns the observations of every endpoint called so far, keyed by
+// endpoint name such as EndpointQuote. Errors count requests that got no
+// response or a 4xx/5xx status. The latency average follows recent calls,
+// which makes it a signal for picking the provider responding faster now.
+// Rejected counts the calls failed without being sent, by the rate limiter,
+// the circuit breaker or Shutdown.
+func (c *OdosClient) Stats() map[string]EndpointStats {
+	return c.stats.Snapshot()
+}
+
+// failed reports whether a call counts as an error in Stats
+func failed(resp *http.Response, err error) bool {
+	return err != nil || resp.StatusCode >= http.StatusBadRequest
+}
@@ -0,0 +1,29 @@
+package odos
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestStats(t *testing.T) {
+	server, client := NewTestServer(TestHandlers{
+		Assemble: func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+		},
+	})
+	defer server.Close()
+
+	client.Swap(NewQuoteRequest(1, nil, nil).WithUserAddr(testUserAddr), false)
+	client.GetTokenPrice(chainId, DAI)
+
+	stats := client.Stats()
+	if quote := stats[EndpointQuote]; quote.Requests != 1 || quote.Errors != 0 || quote.LatencyEMA <= 0 {
+		t.Errorf("Stats()[quote] = %+v", quote)
+	}
+	if assemble := stats[EndpointAssemble]; assemble.Requests != 1 || assemble.Errors != 1 {
+		t.Errorf("Stats()[assemble] = %+v", assemble)
+	}
+	if price := stats[EndpointTokenPrice]; price.Requests != 1 {
+		t.Errorf("Stats()[token_price] = %+v", price)
+	}
+}
@@ -0,0 +1,195 @@
+package odos
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ErrSchemaMismatch is returned in strict decode mode for a response that
+// doesn't match the expected schema
+var ErrSchemaMismatch = errors.New("odos: response doesn't match schema")
+
+// WithStrictDecode makes the quote, assemble, price and gas price responses
+// fail with ErrSchemaMismatch when they carry unknown fields, miss required
+// fields or hold amounts that aren't integers, instead of silently zero
+// valuing what doesn't match
+func WithStrictDecode() Option {
+	return func(c *OdosClient) {
+		c.strict = true
+	}
+}
+
+// strictSchema is implemented by the responses checked in strict mode
+type strictSchema interface {
+	// requiredFields lists the top level fields that must be present and not null
+	requiredFields() []string
+	// validate checks the decoded values, e.g. that amounts parse
+	validate() error
+}
+
+// decodeStrict decodes body into v like decodeBody, then checks it against
+// the schema of v when strict decoding is enabled
+func (c *OdosClient) decodeStrict(statusCode int, body []byte, v interface{}) error {
+	if err := c.decodeBody(statusCode, body, v); err != nil {
+		return err
+	}
+	if !c.strict {
+		return nil
+	}
+
+	var problems []string
+	if unknown := unknownFields(body, reflect.TypeOf(v), ""); len(unknown) > 0 {
+		sort.Strings(unknown)
+		problems = append(problems, "unknown fields "+strings.Join(unknown, ", "))
+	}
+
+	if schema, ok := v.(strictSchema); ok {
+		var fields map[string]json.RawMessage
+		json.Unmarshal(body, &fields)
+
+		var missing []string
+		for _, name := range schema.requiredFields() {
+			if raw, ok := fields[name]; !ok || string(raw) == "null" {
+				missing = append(missing, name)
+			}
+		}
+		if len(missing) > 0 {
+			problems = append(problems, "missing fields "+strings.Join(missing, ", "))
+		}
+
+		if err := schema.validate(); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("%w: %s", ErrSchemaMismatch, strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// unknownFields returns the paths of the object keys in data that have no
+// matching field in t, descending into nested objects and arrays. Unlike
+// json.Decoder.DisallowUnknownFields it also covers the types decoded by a
+// custom UnmarshalJSON, such as QuoteResponse.
+func unknownFields(data []byte, t reflect.Type, path string) []string {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	var unknown []string
+	switch t.Kind() {
+	case reflect.Struct:
+		var fields map[string]json.RawMessage
+		if json.Unmarshal(data, &fields) != nil {
+			return nil
+		}
+
+		known := jsonFields(t)
+		for name, raw := range fields {
+			fieldType, ok := known[name]
+			if !ok {
+				unknown = append(unknown, path+name)
+				continue
+			}
+			unknown = append(unknown, unknownFields(raw, fieldType, path+name+".")...)
+		}
+	case reflect.Slice, reflect.Array:
+		var items []json.RawMessage
+		if json.Unmarshal(data, &items) != nil {
+			return nil
+		}
+		for i, raw := range items {
+			unknown = append(unknown, unknownFields(raw, t.Elem(), fmt.Sprintf("%s%d.", path, i))...)
+		}
+	case reflect.Map:
+		var items map[string]json.RawMessage
+		if json.Unmarshal(data, &items) != nil {
+			return nil
+		}
+		for key, raw := range items {
+			unknown = append(unknown, unknownFields(raw, t.Elem(), path+key+".")...)
+		}
+	}
+	return unknown
+}
+
+// jsonFields maps the JSON names of the fields of struct type t to their type
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields[name] = field.Type
+	}
+	return fields
+}
+
+func (q *QuoteResponse) requiredFields() []string {
+	return []string{"inTokens", "outTokens", "inAmounts", "outAmounts", "gasEstimate", "blockNumber"}
+}
+
+func (q *QuoteResponse) validate() error {
+	if len(q.InTokens) != len(q.InAmounts) || len(q.OutTokens) != len(q.OutAmounts) {
+		return fmt.Errorf("%d in tokens for %d amounts, %d out tokens for %d amounts",
+			len(q.InTokens), len(q.InAmounts), len(q.OutTokens), len(q.OutAmounts))
+	}
+	if _, err := q.InAmountsInt(); err != nil {
+		return err
+	}
+	_, err := q.OutAmountsInt()
+	return err
+}
+
+func (a *AssembleResponse) requiredFields() []string {
+	return []string{"blockNumber", "inputTokens", "outputTokens", "transaction"}
+}
+
+func (a *AssembleResponse) validate() error {
+	if a.Transaction.To == "" || a.Transaction.Data == "" {
+		return fmt.Errorf("transaction without to or data")
+	}
+	if a.Transaction.Value != "" {
+		if _, err := parseAmounts([]string{a.Transaction.Value}); err != nil {
+			return fmt.Errorf("transaction value: %w", err)
+		}
+	}
+	if _, err := a.InputAmounts(); err != nil {
+		return err
+	}
+	_, err := a.OutputAmounts()
+	return err
+}
+
+func (p *PriceResponse) requiredFields() []string {
+	return []string{"currencyId", "price"}
+}
+
+func (p *PriceResponse) validate() error {
+	return nil
+}
+
+func (g *GasPriceResponse) requiredFields() []string {
+	return []string{"chainId", "prices"}
+}
+
+func (g *GasPriceResponse) validate() error {
+	if len(g.Prices) == 0 {
+		return fmt.Errorf("no gas price level")
+	}
+	return nil
+}
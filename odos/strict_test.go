@@ -0,0 +1,82 @@
+package odos
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestStrictDecode_CannedResponses(t *testing.T) {
+	server, client := NewTestServer(TestHandlers{}, WithStrictDecode())
+	defer server.Close()
+
+	quote, err := client.Quote(NewQuoteRequest(1, nil, nil))
+	if err != nil {
+		t.Fatalf("Quote() error = %v", err)
+	}
+	if _, err := client.Assemble(testUserAddr, quote.PathId, true); err != nil {
+		t.Errorf("Assemble() error = %v", err)
+	}
+	if _, err := client.GetTokenPrice(chainId, DAI); err != nil {
+		t.Errorf("GetTokenPrice() error = %v", err)
+	}
+	if _, err := client.GetGasPrice(1); err != nil {
+		t.Errorf("GetGasPrice() error = %v", err)
+	}
+}
+
+func TestStrictDecode_Mismatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		wantMsg string
+	}{
+		{
+			name:    "unknown field",
+			body:    strings.Replace(cannedQuoteResponse, `"pathId"`, `"outAmountsMin":["1"],"pathId"`, 1),
+			wantMsg: "unknown fields outAmountsMin",
+		},
+		{
+			name:    "unknown nested field",
+			body:    `{"inTokens":[],"outTokens":[],"inAmounts":[],"outAmounts":[],"gasEstimate":1,"blockNumber":1,"pathViz":{"nodes":[{"symbol":"DAI","color":"red"}]}}`,
+			wantMsg: "unknown fields pathViz.nodes.0.color",
+		},
+		{
+			name:    "missing field",
+			body:    strings.Replace(cannedQuoteResponse, `"outAmounts":["874518826958614826"],`, ``, 1),
+			wantMsg: "missing fields outAmounts",
+		},
+		{
+			name:    "decimal amount",
+			body:    strings.Replace(cannedQuoteResponse, `"874518826958614826"`, `"0.874518826958614826"`, 1),
+			wantMsg: "invalid amount",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handlers := TestHandlers{
+				Quote: func(w http.ResponseWriter, r *http.Request) {
+					w.Write([]byte(tt.body))
+				},
+			}
+
+			server, client := NewTestServer(handlers)
+			defer server.Close()
+			if _, err := client.Quote(NewQuoteRequest(1, nil, nil)); err != nil {
+				t.Fatalf("Quote() without strict decode error = %v", err)
+			}
+
+			strictServer, strictClient := NewTestServer(handlers, WithStrictDecode())
+			defer strictServer.Close()
+			_, err := strictClient.Quote(NewQuoteRequest(1, nil, nil))
+			if !errors.Is(err, ErrSchemaMismatch) {
+				t.Fatalf("Quote() error = %v, want %v", err, ErrSchemaMismatch)
+			}
+			if !strings.Contains(err.Error(), tt.wantMsg) {
+				t.Errorf("Quote() error = %q, want it to contain %q", err, tt.wantMsg)
+			}
+		})
+	}
+}
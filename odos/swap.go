@@ -0,0 +1,44 @@
+package odos
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrEmptyPathId is returned when a quote comes back without a path id to assemble
+var ErrEmptyPathId = errors.New("odos: quote returned an empty path id")
+
+// Swap quotes req and assembles the returned path for req.UserAddr
+func (c *OdosClient) Swap(req *QuoteRequest, simulate bool) (*AssembleResponse, error) {
+	if req.UserAddr == "" {
+		return nil, fmt.Errorf("user address is required to assemble a swap")
+	}
+
+	quoteResp, err := c.Quote(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to quote swap: %w", err)
+	}
+
+	if quoteResp.PathId == "" {
+		return nil, ErrEmptyPathId
+	}
+
+	assembleResp, err := c.AssembleQuote(req.UserAddr, quoteResp, simulate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to assemble swap: %w", err)
+	}
+
+	return assembleResp, nil
+}
+
+// AssembleOrRequote quotes and assembles req like Swap, quoting again once
+// when the path expires before it could be assembled
+func (c *OdosClient) AssembleOrRequote(req *QuoteRequest, simulate bool) (*AssembleResponse, error) {
+	assembleResp, err := c.Swap(req, simulate)
+	if errors.Is(err, ErrPathExpired) {
+		c.logger.Warn().Msg("path expired before assemble, quoting again")
+		return c.Swap(req, simulate)
+	}
+
+	return assembleResp, err
+}
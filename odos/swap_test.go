@@ -0,0 +1,96 @@
+package odos
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const testUserAddr = "0x163A5EC5e9C32238d075E2D829fE9fA87451e3b7"
+
+func TestSwap(t *testing.T) {
+	tests := []struct {
+		name      string
+		pathId    string
+		wantErr   error
+		wantAsked bool
+	}{
+		{name: "quote and assemble", pathId: "9c2294c5e076d888e149c764f832738b", wantAsked: true},
+		{name: "empty path id", pathId: "", wantErr: ErrEmptyPathId},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var assembled AssembleRequest
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/sor/quote/v2":
+					json.NewEncoder(w).Encode(QuoteResponse{PathId: tt.pathId})
+				case "/sor/assemble":
+					json.NewDecoder(r.Body).Decode(&assembled)
+					w.Write([]byte(`{"transaction":{"to":"0xCf5540fFFCdC3d510B18bFcA6d2b9987b0772559"}}`))
+				}
+			}))
+			defer server.Close()
+
+			client := NewClient(server.URL)
+			req := NewQuoteRequest(1, nil, nil).WithUserAddr(testUserAddr)
+			_, err := client.Swap(req, true)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Swap() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if tt.wantAsked {
+				if assembled.PathId != tt.pathId || assembled.UserAddr != testUserAddr || !assembled.Simulate {
+					t.Errorf("assemble request = %+v", assembled)
+				}
+			}
+		})
+	}
+}
+
+func TestAssembleOrRequote(t *testing.T) {
+	tests := []struct {
+		name          string
+		expiredTimes  int
+		wantErr       error
+		wantQuotes    int
+		wantAssembles int
+	}{
+		{name: "fresh path", expiredTimes: 0, wantQuotes: 1, wantAssembles: 1},
+		{name: "expired once", expiredTimes: 1, wantQuotes: 2, wantAssembles: 2},
+		{name: "expired twice", expiredTimes: 2, wantErr: ErrPathExpired, wantQuotes: 2, wantAssembles: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var quotes, assembles int
+			server, client := NewTestServer(TestHandlers{
+				Quote: func(w http.ResponseWriter, r *http.Request) {
+					quotes++
+					w.Write([]byte(`{"pathId":"abc"}`))
+				},
+				Assemble: func(w http.ResponseWriter, r *http.Request) {
+					assembles++
+					if assembles <= tt.expiredTimes {
+						w.WriteHeader(http.StatusBadRequest)
+						w.Write([]byte(`{"detail":"Path not found or expired","traceId":"t","errorCode":4001}`))
+						return
+					}
+					w.Write([]byte(`{"transaction":{}}`))
+				},
+			})
+			defer server.Close()
+
+			_, err := client.AssembleOrRequote(NewQuoteRequest(1, nil, nil).WithUserAddr(testUserAddr), false)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("AssembleOrRequote() error = %v, want %v", err, tt.wantErr)
+			}
+			if quotes != tt.wantQuotes || assembles != tt.wantAssembles {
+				t.Errorf("quotes = %d, assembles = %d, want %d, %d", quotes, assembles, tt.wantQuotes, tt.wantAssembles)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package odos
+
+import (
+	"net/http"
+	"net/http/httptest"
+)
+
+// Canned responses served by NewTestServer when no handler is set
+const (
+	cannedQuoteResponse = `{"inTokens":["0x6B175474E89094C44Da98b954EedeAC495271d0F"],"outTokens":["0x9D39A5DE30e57443BfF2A8307A4256c8797A3497"],` +
+		`"inAmounts":["1000000000000000000"],"outAmounts":["874518826958614826"],"gasEstimate":235623,"dataGasEstimate":0,"gweiPerGas":6.27,` +
+		`"gasEstimateValue":3.78,"inValues":[1.0001],"outValues":[0.9998],"netOutValue":-2.78,"priceImpact":0.0003,"percentDiff":-0.03,` +
+		`"partnerFeePercent":0,"pathId":"9c2294c5e076d888e149c764f832738b","blockNumber":21000000}`
+	cannedAssembleResponse = `{"deprecated":null,"blockNumber":21000000,"gasEstimate":235623,"gasEstimateValue":3.78,` +
+		`"inputTokens":[{"tokenAddress":"0x6B175474E89094C44Da98b954EedeAC495271d0F","amount":"1000000000000000000"}],` +
+		`"outputTokens":[{"tokenAddress":"0x9D39A5DE30e57443BfF2A8307A4256c8797A3497","amount":"874518826958614826"}],` +
+		`"netOutValue":-2.78,"outValues":["0.9998"],"transaction":{"gas":353434,"gasPrice":6270000000,"value":"0",` +
+		`"to":"0xCf5540fFFCdC3d510B18bFcA6d2b9987b0772559","from":"0x163A5EC5e9C32238d075E2D829fE9fA87451e3b7","data":"0x83bd37f9","nonce":0,"chainId":1},` +
+		`"simulation":{"isSuccess":true,"amountsOut":[874518826958614826],"gasEstimate":235623,"simulationError":""}}`
+	cannedPriceResponse    = `{"currencyId":"USD","price":1.0001}`
+	cannedGasPriceResponse = `{"chainId":1,"baseFee":6.1,"prices":[{"fee":6.27,"sizeFactor":1}]}`
+	cannedChainsResponse   = `{"chains":[1,10,56,137,250,324,8453,34443,42161,43114,59144,534352]}`
+	cannedTokensResponse   = `{"tokenMap":{"0x6B175474E89094C44Da98b954EedeAC495271d0F":{"name":"Dai Stablecoin","symbol":"DAI","decimals":18,` +
+		`"assetId":"dai","assetType":"erc20","protocolId":"","isRebasing":false},"0x9D39A5DE30e57443BfF2A8307A4256c8797A3497":{"name":"Staked USDe",` +
+		`"symbol":"sUSDe","decimals":18,"assetId":"susde","assetType":"erc20","protocolId":"","isRebasing":false}}}`
+	cannedSourcesResponse = `["Uniswap V2","Uniswap V3","Curve Stable","Balancer V2 Weighted","Maker PSM","Hashflow"]`
+)
+
+// TestHandlers overrides the responses of NewTestServer per endpoint, a nil
+// handler serves a canned successful response
+type TestHandlers struct {
+	Quote    http.HandlerFunc // POST /sor/quote/v2 and /sor/quote/v3
+	Assemble http.HandlerFunc // POST /sor/assemble
+	Price    http.HandlerFunc // GET /pricing/token/{chainId}/{tokenAddr}
+	GasPrice http.HandlerFunc // GET /gas/price/{chainId}
+	Tokens   http.HandlerFunc // GET /info/tokens/{chainId}
+	Chains   http.HandlerFunc // GET /info/chains
+	Sources  http.HandlerFunc // GET /info/liquidity-sources/{chainId}
+}
+
+// NewTestServer starts an httptest server mimicking the Odos API and returns
+// it with a client pointed at it, letting tests run without network access.
+// The caller must Close the server.
+func NewTestServer(handlers TestHandlers, opts ...Option) (*httptest.Server, *OdosClient) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/sor/quote/v2", handlerOrCanned(handlers.Quote, cannedQuoteResponse))
+	mux.HandleFunc("/sor/quote/v3", handlerOrCanned(handlers.Quote, cannedQuoteResponse))
+	mux.HandleFunc("/sor/assemble", handlerOrCanned(handlers.Assemble, cannedAssembleResponse))
+	mux.HandleFunc("/pricing/token/", handlerOrCanned(handlers.Price, cannedPriceResponse))
+	mux.HandleFunc("/gas/price/", handlerOrCanned(handlers.GasPrice, cannedGasPriceResponse))
+	mux.HandleFunc("/info/tokens/", handlerOrCanned(handlers.Tokens, cannedTokensResponse))
+	mux.HandleFunc("/info/chains", handlerOrCanned(handlers.Chains, cannedChainsResponse))
+	mux.HandleFunc("/info/liquidity-sources/", handlerOrCanned(handlers.Sources, cannedSourcesResponse))
+
+	server := httptest.NewServer(mux)
+	client := NewClientWithOptions(append([]Option{WithBaseURL(server.URL)}, opts...)...)
+	return server, client
+}
+
+func handlerOrCanned(handler http.HandlerFunc, canned string) http.HandlerFunc {
+	if handler != nil {
+		return handler
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(canned))
+	}
+}
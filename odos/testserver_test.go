@@ -0,0 +1,37 @@
+package odos
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestNewTestServer(t *testing.T) {
+	server, client := NewTestServer(TestHandlers{})
+	defer server.Close()
+
+	resp, err := client.Swap(NewQuoteRequest(1, nil, nil).WithUserAddr(testUserAddr), true)
+	if err != nil {
+		t.Fatalf("Swap() error = %v", err)
+	}
+	if !resp.Simulation.IsSuccess || resp.Transaction.To == "" {
+		t.Errorf("Swap() = %+v", resp)
+	}
+
+	price, err := client.GetTokenPrice(chainId, DAI)
+	if err != nil || price.Price == 0 {
+		t.Errorf("GetTokenPrice() = %v, %v", price, err)
+	}
+}
+
+func TestNewTestServer_Handlers(t *testing.T) {
+	server, client := NewTestServer(TestHandlers{
+		Quote: func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusTooManyRequests)
+		},
+	})
+	defer server.Close()
+
+	if _, err := client.Quote(NewQuoteRequest(1, nil, nil)); err == nil {
+		t.Errorf("Quote() expected the overriding handler error")
+	}
+}
@@ -0,0 +1,46 @@
+package odos
+
+import (
+	"net"
+	"time"
+)
+
+// Timeouts bounds the phases of a request, a zero duration keeps the
+// current setting of the phase
+type Timeouts struct {
+	// Dial bounds establishing the TCP connection
+	Dial time.Duration
+	// TLSHandshake bounds the TLS handshake
+	TLSHandshake time.Duration
+	// ResponseHeader bounds waiting for the response headers once the
+	// request is written, i.e. the server processing time
+	ResponseHeader time.Duration
+	// Total bounds the whole request including reading the body, like
+	// WithTimeout
+	Total time.Duration
+}
+
+// WithTimeouts sets per phase timeouts, e.g. a short Dial to fail fast on
+// unreachable hosts with a longer ResponseHeader for slow simulations. The
+// transport of the HTTP client is replaced by a configured copy, with a
+// connection pool of its own.
+func WithTimeouts(timeouts Timeouts) Option {
+	return func(c *OdosClient) {
+		transport := c.ownTransport()
+
+		if timeouts.Dial > 0 {
+			dialer := &net.Dialer{Timeout: timeouts.Dial, KeepAlive: 30 * time.Second}
+			transport.DialContext = dialer.DialContext
+		}
+		if timeouts.TLSHandshake > 0 {
+			transport.TLSHandshakeTimeout = timeouts.TLSHandshake
+		}
+		if timeouts.ResponseHeader > 0 {
+			transport.ResponseHeaderTimeout = timeouts.ResponseHeader
+		}
+
+		if timeouts.Total > 0 {
+			c.httpClient.Timeout = timeouts.Total
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package odos
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestWithTimeouts(t *testing.T) {
+	client := NewClientWithOptions(WithTimeouts(Timeouts{
+		TLSHandshake:   2 * time.Second,
+		ResponseHeader: 30 * time.Second,
+		Total:          time.Minute,
+	}))
+
+	transport, ok := client.httpClient.Transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("transport = %T, want *http.Transport", client.httpClient.Transport)
+	}
+	if transport == http.DefaultTransport {
+		t.Errorf("WithTimeouts() modified http.DefaultTransport")
+	}
+	if transport.TLSHandshakeTimeout != 2*time.Second || transport.ResponseHeaderTimeout != 30*time.Second {
+		t.Errorf("transport timeouts = %v, %v", transport.TLSHandshakeTimeout, transport.ResponseHeaderTimeout)
+	}
+	if client.httpClient.Timeout != time.Minute {
+		t.Errorf("client timeout = %v, want 1m", client.httpClient.Timeout)
+	}
+}
+
+func TestWithTimeouts_ResponseHeader(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+		w.Write([]byte(cannedPriceResponse))
+	}))
+	defer server.Close()
+
+	client := NewClientWithOptions(WithBaseURL(server.URL), WithTimeouts(Timeouts{ResponseHeader: 20 * time.Millisecond}))
+	if _, err := client.GetTokenPrice(chainId, DAI); err == nil {
+		t.Fatal("GetTokenPrice() succeeded past the response header timeout")
+	}
+}
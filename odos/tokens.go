@@ -0,0 +1,220 @@
+package odos
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ThreeAndTwo/dex-swap-api-helper/chains"
+	"github.com/ThreeAndTwo/dex-swap-api-helper/internal/address"
+)
+
+// _tokenListMaxAge is how long GetTokenInfo serves a token list before
+// fetching it again. A token missing from the list triggers a refetch once
+// the list is older than _tokenListMissAge, picking up newly listed tokens.
+const (
+	_tokenListMaxAge  = time.Hour
+	_tokenListMissAge = time.Minute
+)
+
+// tokenListResponse represents the response from the token list endpoint,
+// which names the TokenInfo fields in camel case
+type tokenListResponse struct {
+	TokenMap map[string]struct {
+		Name       string `json:"name"`
+		Symbol     string `json:"symbol"`
+		Decimals   int    `json:"decimals"`
+		AssetID    string `json:"assetId"`
+		AssetType  string `json:"assetType"`
+		IsRebasing bool   `json:"isRebasing"`
+	} `json:"tokenMap"`
+}
+
+// tokenCache holds the token lists per chain, keyed by lowercase address
+type tokenCache struct {
+	mu       sync.Mutex
+	lists    map[chains.ChainID]cachedTokenList
+	inflight map[chains.ChainID]*tokenListFetch
+}
+
+type cachedTokenList struct {
+	tokens    map[string]TokenInfo
+	fetchedAt time.Time
+}
+
+// tokenListFetch is a token list download shared by the callers refreshing
+// the same chain while it runs
+type tokenListFetch struct {
+	done chan struct{}
+	list cachedTokenList
+	err  error
+}
+
+func newTokenCache() *tokenCache {
+	return &tokenCache{
+		lists:    make(map[chains.ChainID]cachedTokenList),
+		inflight: make(map[chains.ChainID]*tokenListFetch),
+	}
+}
+
+// cached returns the cached token list of chainID
+func (t *tokenCache) cached(chainID chains.ChainID) (cachedTokenList, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	list, ok := t.lists[chainID]
+	return list, ok
+}
+
+// GetTokenList fetches the tokens Odos supports on a chain, keyed by address
+// /info/tokens/{chainId}
+func (c *OdosClient) GetTokenList(chainID chains.ChainID) (map[string]TokenInfo, error) {
+	url := fmt.Sprintf("%s/info/tokens/%d", c.baseURL, chainID)
+
+	request, err := c.newRequest("GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.do(EndpointTokens, request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token list: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("status code %d, failed to read error response: %w", resp.StatusCode, err)
+		}
+		return nil, newAPIError(resp.StatusCode, body)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	var listResp tokenListResponse
+	if err := c.decodeBody(resp.StatusCode, body, &listResp); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	tokens := make(map[string]TokenInfo, len(listResp.TokenMap))
+	for addr, token := range listResp.TokenMap {
+		tokens[addr] = TokenInfo{
+			Name:       token.Name,
+			Symbol:     token.Symbol,
+			Decimals:   token.Decimals,
+			AssetID:    token.AssetID,
+			AssetType:  token.AssetType,
+			IsRebasing: token.IsRebasing,
+		}
+	}
+	return tokens, nil
+}
+
+// GetTokenInfo returns the metadata of a token, such as its decimals and
+// symbol. The token list of the chain is fetched once and kept for an hour,
+// a token missing from it fails with ErrUnknownToken.
+func (c *OdosClient) GetTokenInfo(chainID chains.ChainID, tokenAddr string) (*TokenInfo, error) {
+	tokenAddr, err := address.Normalize(toOdosToken(tokenAddr))
+	if err != nil {
+		return nil, err
+	}
+
+	list, ok := c.tokens.cached(chainID)
+	info, found := list.tokens[tokenAddr]
+	age := time.Since(list.fetchedAt)
+	if !ok || age > _tokenListMaxAge || (!found && age > _tokenListMissAge) {
+		if list, err = c.refreshTokenList(chainID); err != nil {
+			return nil, err
+		}
+		info, found = list.tokens[tokenAddr]
+	}
+
+	if !found {
+		return nil, fmt.Errorf("%w: %s on chain %d", ErrUnknownToken, tokenAddr, chainID)
+	}
+	return &info, nil
+}
+
+// GetTokens returns the tokens Odos supports on a chain, keyed by lowercase
+// address. The list is shared with GetTokenInfo and kept for an hour, so the
+// large payload is only fetched and decoded once. Use FilterTokens to narrow
+// it down by symbol.
+func (c *OdosClient) GetTokens(chainID chains.ChainID) (map[string]TokenInfo, error) {
+	list, ok := c.tokens.cached(chainID)
+	if !ok || time.Since(list.fetchedAt) > _tokenListMaxAge {
+		var err error
+		if list, err = c.refreshTokenList(chainID); err != nil {
+			return nil, err
+		}
+	}
+
+	tokens := make(map[string]TokenInfo, len(list.tokens))
+	for addr, info := range list.tokens {
+		tokens[addr] = info
+	}
+	return tokens, nil
+}
+
+// FilterTokens returns the tokens whose symbol contains symbol, ignoring
+// case. An empty symbol matches every token.
+func FilterTokens(tokens map[string]TokenInfo, symbol string) map[string]TokenInfo {
+	symbol = strings.ToLower(symbol)
+
+	filtered := make(map[string]TokenInfo)
+	for addr, info := range tokens {
+		if strings.Contains(strings.ToLower(info.Symbol), symbol) {
+			filtered[addr] = info
+		}
+	}
+	return filtered
+}
+
+// refreshTokenList fetches the token list of chainID into the cache. The
+// lock isn't held while fetching, so a slow download doesn't hold up the
+// lookups of other chains, and the callers refreshing the same chain at once
+// share a single fetch.
+func (c *OdosClient) refreshTokenList(chainID chains.ChainID) (cachedTokenList, error) {
+	c.tokens.mu.Lock()
+	if fetch, ok := c.tokens.inflight[chainID]; ok {
+		c.tokens.mu.Unlock()
+		<-fetch.done
+		return fetch.list, fetch.err
+	}
+	fetch := &tokenListFetch{done: make(chan struct{})}
+	c.tokens.inflight[chainID] = fetch
+	c.tokens.mu.Unlock()
+
+	fetch.list, fetch.err = c.fetchTokenList(chainID)
+
+	c.tokens.mu.Lock()
+	if fetch.err == nil {
+		c.tokens.lists[chainID] = fetch.list
+	}
+	delete(c.tokens.inflight, chainID)
+	c.tokens.mu.Unlock()
+	close(fetch.done)
+	return fetch.list, fetch.err
+}
+
+// fetchTokenList fetches the token list of chainID, keyed by lowercase address
+func (c *OdosClient) fetchTokenList(chainID chains.ChainID) (cachedTokenList, error) {
+	tokens, err := c.GetTokenList(chainID)
+	if err != nil {
+		return cachedTokenList{}, err
+	}
+
+	list := cachedTokenList{
+		tokens:    make(map[string]TokenInfo, len(tokens)),
+		fetchedAt: time.Now(),
+	}
+	for addr, info := range tokens {
+		list.tokens[strings.ToLower(addr)] = info
+	}
+	return list, nil
+}
@@ -0,0 +1,129 @@
+package odos
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGetTokenInfo(t *testing.T) {
+	var calls int
+	server, client := NewTestServer(TestHandlers{
+		Tokens: func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			if r.URL.Path != "/info/tokens/1" {
+				t.Errorf("path = %s", r.URL.Path)
+			}
+			w.Write([]byte(cannedTokensResponse))
+		},
+	})
+	defer server.Close()
+
+	info, err := client.GetTokenInfo(1, DAI)
+	if err != nil {
+		t.Fatalf("GetTokenInfo() error = %v", err)
+	}
+	if info.Symbol != "DAI" || info.Decimals != 18 || info.AssetID != "dai" {
+		t.Errorf("GetTokenInfo() = %+v", info)
+	}
+
+	if _, err := client.GetTokenInfo(1, sUSDe); err != nil {
+		t.Fatalf("GetTokenInfo() error = %v", err)
+	}
+	if _, err := client.GetTokenInfo(1, wstETH); !errors.Is(err, ErrUnknownToken) {
+		t.Errorf("GetTokenInfo(wstETH) error = %v, want ErrUnknownToken", err)
+	}
+	if calls != 1 {
+		t.Errorf("token list fetched %d times, want 1", calls)
+	}
+}
+
+func TestGetTokens(t *testing.T) {
+	var calls int
+	server, client := NewTestServer(TestHandlers{
+		Tokens: func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			w.Write([]byte(cannedTokensResponse))
+		},
+	})
+	defer server.Close()
+
+	tokens, err := client.GetTokens(1)
+	if err != nil {
+		t.Fatalf("GetTokens() error = %v", err)
+	}
+	if len(tokens) != 2 || tokens[strings.ToLower(DAI)].Symbol != "DAI" {
+		t.Errorf("GetTokens() = %+v", tokens)
+	}
+
+	delete(tokens, strings.ToLower(DAI))
+	if _, err := client.GetTokenInfo(1, DAI); err != nil {
+		t.Fatalf("GetTokenInfo() error = %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("token list fetched %d times, want 1", calls)
+	}
+
+	tokens, _ = client.GetTokens(1)
+	filtered := FilterTokens(tokens, "usd")
+	if len(filtered) != 1 || filtered[strings.ToLower(sUSDe)].Symbol != "sUSDe" {
+		t.Errorf("FilterTokens(usd) = %+v", filtered)
+	}
+	if len(FilterTokens(tokens, "")) != 2 {
+		t.Error("FilterTokens(\"\") dropped tokens")
+	}
+}
+
+func TestGetTokenInfo_SlowFetch(t *testing.T) {
+	release := make(chan struct{})
+	fetching := make(chan struct{})
+	var fetchesChain1 atomic.Int32
+	server, client := NewTestServer(TestHandlers{
+		Tokens: func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/info/tokens/1" && fetchesChain1.Add(1) == 1 {
+				close(fetching)
+				<-release
+			}
+			w.Write([]byte(cannedTokensResponse))
+		},
+	})
+	defer server.Close()
+
+	if _, err := client.GetTokenInfo(10, DAI); err != nil {
+		t.Fatalf("GetTokenInfo(10) error = %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			client.GetTokens(1)
+		}()
+	}
+	<-fetching
+
+	done := make(chan error)
+	go func() {
+		_, err := client.GetTokenInfo(10, DAI)
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("GetTokenInfo(10) error = %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("cached GetTokenInfo(10) waited for the token list of chain 1")
+	}
+
+	close(release)
+	wg.Wait()
+	if n := fetchesChain1.Load(); n != 1 {
+		t.Errorf("token list of chain 1 fetched %d times, want 1", n)
+	}
+}
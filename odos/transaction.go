@@ -0,0 +1,192 @@
+package odos
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/ThreeAndTwo/dex-swap-api-helper/internal/address"
+	"github.com/ThreeAndTwo/dex-swap-api-helper/internal/codec"
+	"github.com/ThreeAndTwo/dex-swap-api-helper/units"
+)
+
+// DynamicFeeTx holds an EIP-1559 transaction with the fields and types of
+// go-ethereum's types.DynamicFeeTx, To being convertible to common.Address.
+// The odos/ethtx module does the conversion for go-ethereum users:
+//
+//	tx := types.NewTx(&types.DynamicFeeTx{
+//		ChainID: t.ChainID, Nonce: t.Nonce, GasTipCap: t.GasTipCap, GasFeeCap: t.GasFeeCap,
+//		Gas: t.Gas, To: (*common.Address)(&t.To), Value: t.Value, Data: t.Data,
+//	})
+type DynamicFeeTx struct {
+	ChainID   *big.Int
+	Nonce     uint64
+	GasTipCap *big.Int // maxPriorityFeePerGas
+	GasFeeCap *big.Int // maxFeePerGas
+	Gas       uint64
+	To        [20]byte
+	Value     *big.Int
+	Data      []byte
+}
+
+// DynamicFeeTx converts the assembled transaction to an EIP-1559
+// transaction. A legacy transaction without 1559 fee fields uses GasPrice as
+// both fee cap and tip cap, paying the same price as the legacy one.
+func (t *Transaction) DynamicFeeTx() (*DynamicFeeTx, error) {
+	if t.Gas < 0 || t.Nonce < 0 {
+		return nil, fmt.Errorf("invalid transaction: gas %d, nonce %d", t.Gas, t.Nonce)
+	}
+
+	to, err := address.Normalize(t.To)
+	if err != nil {
+		return nil, fmt.Errorf("invalid transaction to: %w", err)
+	}
+	toBytes, _ := hex.DecodeString(to[2:])
+
+	value, err := t.ValueBig()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := hex.DecodeString(strings.TrimPrefix(t.Data, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid transaction data: %w", err)
+	}
+
+	feeCap, tipCap := t.MaxFeePerGas, t.MaxPriorityFeePerGas
+	if feeCap == 0 {
+		feeCap, tipCap = t.GasPrice, t.GasPrice
+	}
+
+	tx := &DynamicFeeTx{
+		ChainID:   big.NewInt(int64(t.ChainId)),
+		Nonce:     uint64(t.Nonce),
+		GasTipCap: big.NewInt(tipCap),
+		GasFeeCap: big.NewInt(feeCap),
+		Gas:       uint64(t.Gas),
+		Value:     value,
+		Data:      data,
+	}
+	copy(tx.To[:], toBytes)
+	return tx, nil
+}
+
+// ValueBig returns the wei sent with the transaction, reading Value in
+// decimal or 0x prefixed hex. An empty Value is 0.
+func (t *Transaction) ValueBig() (*big.Int, error) {
+	if t.Value == "" {
+		return new(big.Int), nil
+	}
+
+	value, err := units.ParseQuantity(t.Value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid transaction value: %w", err)
+	}
+	return value, nil
+}
+
+// UnmarshalJSON decodes the transaction, accepting its numeric fields as
+// JSON numbers, decimal strings or hex strings and normalizing Value to
+// decimal
+func (t *Transaction) UnmarshalJSON(data []byte) error {
+	return t.unmarshalWith(codec.Standard, data)
+}
+
+func (t *Transaction) unmarshalWith(jsonCodec Codec, data []byte) error {
+	type fields Transaction
+	var tx struct {
+		fields
+		Gas                  quantity `json:"gas"`
+		GasPrice             quantity `json:"gasPrice"`
+		Value                quantity `json:"value"`
+		Nonce                quantity `json:"nonce"`
+		MaxFeePerGas         quantity `json:"maxFeePerGas"`
+		MaxPriorityFeePerGas quantity `json:"maxPriorityFeePerGas"`
+	}
+	tx.fields = fields(*t)
+	if err := jsonCodec.Unmarshal(data, &tx); err != nil {
+		return err
+	}
+
+	*t = Transaction(tx.fields)
+	for _, field := range []struct {
+		name string
+		q    quantity
+		dst  *int64
+	}{
+		{"gas", tx.Gas, &t.Gas},
+		{"gasPrice", tx.GasPrice, &t.GasPrice},
+		{"nonce", tx.Nonce, &t.Nonce},
+		{"maxFeePerGas", tx.MaxFeePerGas, &t.MaxFeePerGas},
+		{"maxPriorityFeePerGas", tx.MaxPriorityFeePerGas, &t.MaxPriorityFeePerGas},
+	} {
+		if field.q.n == nil {
+			continue
+		}
+		if !field.q.n.IsInt64() {
+			return fmt.Errorf("transaction %s out of range: %s", field.name, field.q.n)
+		}
+		*field.dst = field.q.n.Int64()
+	}
+	if tx.Value.n != nil {
+		t.Value = tx.Value.n.String()
+	}
+	return nil
+}
+
+// unmarshalWith decodes the response, decoding its transaction and
+// simulation with jsonCodec as well
+func (a *AssembleResponse) unmarshalWith(jsonCodec Codec, data []byte) error {
+	type fields AssembleResponse
+	var assemble struct {
+		fields
+		Transaction json.RawMessage `json:"transaction"`
+		Simulation  json.RawMessage `json:"simulation"`
+	}
+	if err := jsonCodec.Unmarshal(data, &assemble); err != nil {
+		return err
+	}
+
+	*a = AssembleResponse(assemble.fields)
+	if len(assemble.Transaction) > 0 {
+		if err := a.Transaction.unmarshalWith(jsonCodec, assemble.Transaction); err != nil {
+			return err
+		}
+	}
+	if len(assemble.Simulation) > 0 {
+		if err := a.Simulation.unmarshalWith(jsonCodec, assemble.Simulation); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// quantity decodes an integer sent as a JSON number, a decimal string or a
+// hex string, n is nil when the field is missing or null
+type quantity struct {
+	n *big.Int
+}
+
+func (q *quantity) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if s == "null" {
+		return nil
+	}
+	if strings.HasPrefix(s, `"`) {
+		unquoted, err := strconv.Unquote(s)
+		if err != nil {
+			return err
+		}
+		s = unquoted
+	}
+
+	n, err := units.ParseQuantity(s)
+	if err != nil {
+		return err
+	}
+	q.n = n
+	return nil
+}
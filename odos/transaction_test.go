@@ -0,0 +1,92 @@
+package odos
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+)
+
+func TestTransaction_DynamicFeeTx(t *testing.T) {
+	var resp AssembleResponse
+	if err := json.Unmarshal([]byte(cannedAssembleResponse), &resp); err != nil {
+		t.Fatal(err)
+	}
+
+	legacy, err := resp.Transaction.DynamicFeeTx()
+	if err != nil {
+		t.Fatalf("DynamicFeeTx() error = %v", err)
+	}
+	if legacy.GasFeeCap.Int64() != 6270000000 || legacy.GasTipCap.Int64() != 6270000000 {
+		t.Errorf("legacy fee caps = %v, %v, want the gas price", legacy.GasFeeCap, legacy.GasTipCap)
+	}
+	if legacy.ChainID.Int64() != 1 || legacy.Gas != 353434 || legacy.Value.Sign() != 0 {
+		t.Errorf("DynamicFeeTx() = %+v", legacy)
+	}
+	if hex.EncodeToString(legacy.To[:]) != "cf5540fffcdc3d510b18bfca6d2b9987b0772559" {
+		t.Errorf("To = %x", legacy.To)
+	}
+	if hex.EncodeToString(legacy.Data) != "83bd37f9" {
+		t.Errorf("Data = %x", legacy.Data)
+	}
+
+	tx := resp.Transaction
+	if err := json.Unmarshal([]byte(`{"maxFeePerGas":20000000000,"maxPriorityFeePerGas":1000000000,"value":"1000000000000000000"}`), &tx); err != nil {
+		t.Fatal(err)
+	}
+	dynamic, err := tx.DynamicFeeTx()
+	if err != nil {
+		t.Fatalf("DynamicFeeTx() error = %v", err)
+	}
+	if dynamic.GasFeeCap.Int64() != 20000000000 || dynamic.GasTipCap.Int64() != 1000000000 {
+		t.Errorf("fee caps = %v, %v", dynamic.GasFeeCap, dynamic.GasTipCap)
+	}
+	if dynamic.Value.String() != "1000000000000000000" {
+		t.Errorf("Value = %v", dynamic.Value)
+	}
+}
+
+func TestTransaction_DynamicFeeTx_Invalid(t *testing.T) {
+	tests := []Transaction{
+		{To: "0x1234"},
+		{To: testUserAddr, Value: "1.5"},
+		{To: testUserAddr, Data: "0xzz"},
+		{To: testUserAddr, Gas: -1},
+	}
+
+	for _, tx := range tests {
+		if _, err := tx.DynamicFeeTx(); err == nil {
+			t.Errorf("DynamicFeeTx() of %+v succeeded", tx)
+		}
+	}
+}
+
+func TestTransaction_HexFields(t *testing.T) {
+	var tx Transaction
+	data := `{"gas":"0x5649a","gasPrice":"6270000000","value":"0xde0b6b3a7640000","nonce":"0x7","chainId":1,` +
+		`"to":"0xCf5540fFFCdC3d510B18bFcA6d2b9987b0772559","data":"0x83bd37f9"}`
+	if err := json.Unmarshal([]byte(data), &tx); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if tx.Gas != 353434 || tx.GasPrice != 6270000000 || tx.Nonce != 7 {
+		t.Errorf("Transaction = %+v", tx)
+	}
+	if tx.Value != "1000000000000000000" {
+		t.Errorf("Value = %q, want decimal", tx.Value)
+	}
+
+	for _, value := range []string{"1000000000000000000", "0xde0b6b3a7640000"} {
+		got, err := (&Transaction{Value: value}).ValueBig()
+		if err != nil || got.String() != "1000000000000000000" {
+			t.Errorf("ValueBig(%q) = %v, %v", value, got, err)
+		}
+	}
+	if got, err := (&Transaction{}).ValueBig(); err != nil || got.Sign() != 0 {
+		t.Errorf("ValueBig() of empty value = %v, %v", got, err)
+	}
+
+	for _, data := range []string{`{"value":"-1"}`, `{"gas":"0xffffffffffffffffff"}`, `{"nonce":1.5}`} {
+		if err := json.Unmarshal([]byte(data), &tx); err == nil {
+			t.Errorf("Unmarshal(%s) succeeded", data)
+		}
+	}
+}
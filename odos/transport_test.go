@@ -0,0 +1,50 @@
+package odos
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestWithTransportConfig(t *testing.T) {
+	client := NewClientWithOptions(
+		WithTimeouts(Timeouts{TLSHandshake: 2 * time.Second}),
+		WithTransportConfig(200, 50, 0),
+	)
+
+	transport, ok := client.httpClient.Transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("transport = %T, want *http.Transport", client.httpClient.Transport)
+	}
+	if transport == http.DefaultTransport {
+		t.Errorf("WithTransportConfig() modified http.DefaultTransport")
+	}
+	if transport.MaxIdleConns != 200 || transport.MaxIdleConnsPerHost != 50 {
+		t.Errorf("idle conns = %d, %d per host, want 200, 50", transport.MaxIdleConns, transport.MaxIdleConnsPerHost)
+	}
+	defaults := http.DefaultTransport.(*http.Transport)
+	if transport.IdleConnTimeout != defaults.IdleConnTimeout {
+		t.Errorf("idle timeout = %v, want the default %v kept", transport.IdleConnTimeout, defaults.IdleConnTimeout)
+	}
+	if transport.TLSHandshakeTimeout != 2*time.Second {
+		t.Errorf("WithTransportConfig() dropped the earlier timeouts")
+	}
+}
+
+func TestOptions_SharedHTTPClient(t *testing.T) {
+	options := map[string]Option{
+		"WithTransportConfig": WithTransportConfig(200, 50, time.Minute),
+		"WithTimeouts":        WithTimeouts(Timeouts{Dial: time.Second, Total: time.Minute}),
+		"WithTimeout":         WithTimeout(time.Minute),
+	}
+	for name, opt := range options {
+		shared := &http.Client{Timeout: time.Second}
+		client := NewClientWithOptions(WithHTTPClient(shared), opt)
+		if shared.Transport != nil || shared.Timeout != time.Second {
+			t.Errorf("%s modified the shared HTTP client: %+v", name, shared)
+		}
+		if client.httpClient == shared {
+			t.Errorf("%s configured the shared HTTP client in place", name)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package odos
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/ThreeAndTwo/dex-swap-api-helper/internal/address"
+)
+
+// Validate checks the request before it is sent, rejecting malformed token
+// and user addresses and invalid referral codes
+func (r *QuoteRequest) Validate() error {
+	for _, in := range r.InputTokens {
+		if err := address.Validate(in.TokenAddress); err != nil {
+			return fmt.Errorf("input token: %w", err)
+		}
+	}
+	for _, out := range r.OutputTokens {
+		if err := address.Validate(out.TokenAddress); err != nil {
+			return fmt.Errorf("output token: %w", err)
+		}
+	}
+	if err := validateProportions(r.OutputTokens); err != nil {
+		return err
+	}
+	if r.UserAddr != "" {
+		if err := address.Validate(r.UserAddr); err != nil {
+			return fmt.Errorf("user address: %w", err)
+		}
+	}
+	if err := ValidateReferralCode(r.ReferralCode); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Validate checks the request before it is sent, rejecting an empty path id,
+// usually left by a failed quote, and a malformed user address
+func (r *AssembleRequest) Validate() error {
+	if r.PathId == "" {
+		return ErrEmptyPathId
+	}
+	if err := address.Validate(r.UserAddr); err != nil {
+		return fmt.Errorf("user address: %w", err)
+	}
+	return nil
+}
+
+// _proportionEpsilon is how far the sum of the output proportions may be
+// from 1, absorbing float rounding of splits like 1/3
+const _proportionEpsilon = 1e-6
+
+// validateProportions checks each output gets a proportion in (0, 1] and
+// that they sum to 1. A request without outputs is left to the server.
+func validateProportions(outputs []OutputToken) error {
+	if len(outputs) == 0 {
+		return nil
+	}
+
+	var sum float64
+	for _, out := range outputs {
+		if !(out.Proportion > 0 && out.Proportion <= 1) {
+			return fmt.Errorf("%w: %s gets %v, must be between 0 and 1", ErrInvalidProportions, out.TokenAddress, out.Proportion)
+		}
+		sum += out.Proportion
+	}
+	if math.Abs(sum-1) > _proportionEpsilon {
+		return fmt.Errorf("%w: sum to %v, must sum to 1", ErrInvalidProportions, sum)
+	}
+	return nil
+}
+
+// ValidateReferralCode checks a referral code fits the uint32 Odos uses on
+// chain. 0 means no referral code and is valid.
+func ValidateReferralCode(code int) error {
+	if code < 0 || int64(code) > math.MaxUint32 {
+		return fmt.Errorf("%w: %d", ErrInvalidReferralCode, code)
+	}
+	return nil
+}
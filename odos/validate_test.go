@@ -0,0 +1,126 @@
+package odos
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestQuoteRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     *QuoteRequest
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			req:  NewQuoteRequest(1, nil, nil).AddInput(DAI, "1").SetSingleOutput(sUSDe).WithUserAddr(testUserAddr),
+		},
+		{
+			name: "no user address",
+			req:  NewQuoteRequest(1, nil, nil).AddInput(DAI, "1").SetSingleOutput(sUSDe),
+		},
+		{
+			name:    "bad input",
+			req:     NewQuoteRequest(1, nil, nil).AddInput("0x6B17", "1").SetSingleOutput(sUSDe),
+			wantErr: true,
+		},
+		{
+			name:    "bad checksum output",
+			req:     NewQuoteRequest(1, nil, nil).AddInput(DAI, "1").SetSingleOutput("0x9d39A5DE30e57443BfF2A8307A4256c8797A3497"),
+			wantErr: true,
+		},
+		{
+			name:    "bad user address",
+			req:     NewQuoteRequest(1, nil, nil).WithUserAddr("0xzz3A5EC5e9C32238d075E2D829fE9fA87451e3b7"),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.req.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidAddress) {
+				t.Errorf("Validate() error = %v, want ErrInvalidAddress", err)
+			}
+		})
+	}
+}
+
+func TestQuoteRequest_ValidateProportions(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     *QuoteRequest
+		wantErr bool
+	}{
+		{name: "single output", req: NewQuoteRequest(1, nil, nil).SetSingleOutput(sUSDe)},
+		{name: "thirds", req: NewQuoteRequest(1, nil, nil).AddOutput(sUSDe, 1.0/3).AddOutput(wstETH, 1.0/3).AddOutput(ezETH, 1.0/3)},
+		{name: "short", req: NewQuoteRequest(1, nil, nil).AddOutput(sUSDe, 0.5).AddOutput(wstETH, 0.4), wantErr: true},
+		{name: "over", req: NewQuoteRequest(1, nil, nil).AddOutput(sUSDe, 0.7).AddOutput(wstETH, 0.4), wantErr: true},
+		{name: "zero", req: NewQuoteRequest(1, nil, nil).AddOutput(sUSDe, 1).AddOutput(wstETH, 0), wantErr: true},
+		{name: "negative", req: NewQuoteRequest(1, nil, nil).AddOutput(sUSDe, 1.5).AddOutput(wstETH, -0.5), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.req.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidProportions) {
+				t.Errorf("Validate() error = %v, want ErrInvalidProportions", err)
+			}
+		})
+	}
+}
+
+func TestAssembleRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     AssembleRequest
+		wantErr error
+	}{
+		{name: "valid", req: AssembleRequest{UserAddr: testUserAddr, PathId: "9c2294c5e076d888e149c764f832738b"}},
+		{name: "empty path id", req: AssembleRequest{UserAddr: testUserAddr}, wantErr: ErrEmptyPathId},
+		{name: "bad user address", req: AssembleRequest{UserAddr: "0x163A", PathId: "abc"}, wantErr: ErrInvalidAddress},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.req.Validate(); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Validate() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	client := NewClient("http://127.0.0.1:1")
+	if _, err := client.Assemble(testUserAddr, "", false); !errors.Is(err, ErrEmptyPathId) {
+		t.Errorf("Assemble() error = %v, want ErrEmptyPathId", err)
+	}
+}
+
+func TestGetTokenPrice_NormalizesAddress(t *testing.T) {
+	var path string
+	server, client := NewTestServer(TestHandlers{
+		Price: func(w http.ResponseWriter, r *http.Request) {
+			path = r.URL.Path
+			w.Write([]byte(cannedPriceResponse))
+		},
+	})
+	defer server.Close()
+
+	if _, err := client.GetTokenPrice(chainId, DAI); err != nil {
+		t.Fatalf("GetTokenPrice() error = %v", err)
+	}
+	if path != "/pricing/token/1/"+strings.ToLower(DAI) {
+		t.Errorf("path = %s", path)
+	}
+
+	if _, err := client.GetTokenPrice(chainId, "DAI"); !errors.Is(err, ErrInvalidAddress) {
+		t.Errorf("GetTokenPrice() error = %v, want ErrInvalidAddress", err)
+	}
+}
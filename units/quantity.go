@@ -0,0 +1,34 @@
+package units
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// ParseQuantity parses a non negative integer given either in decimal or as
+// 0x prefixed hex, the two forms the aggregator APIs use for transaction
+// values, e.g. "1000000000000000000" and "0xde0b6b3a7640000" are both 1 ETH
+// in wei. Signs, fractions and empty strings fail with ErrInvalidAmount.
+func ParseQuantity(s string) (*big.Int, error) {
+	digits, base := s, 10
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		digits, base = s[2:], 16
+	}
+	if digits == "" || strings.IndexFunc(digits, func(r rune) bool { return !isDigit(r, base) }) >= 0 {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidAmount, s)
+	}
+
+	n, _ := new(big.Int).SetString(digits, base)
+	return n, nil
+}
+
+func isDigit(r rune, base int) bool {
+	switch {
+	case r >= '0' && r <= '9':
+		return true
+	case base == 16:
+		return r >= 'a' && r <= 'f' || r >= 'A' && r <= 'F'
+	}
+	return false
+}
@@ -0,0 +1,31 @@
+package units
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseQuantity(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "0", want: "0"},
+		{in: "1000000000000000000", want: "1000000000000000000"},
+		{in: "0xde0b6b3a7640000", want: "1000000000000000000"},
+		{in: "0XDE0B6B3A7640000", want: "1000000000000000000"},
+		{in: "0x0", want: "0"},
+	}
+	for _, tt := range tests {
+		got, err := ParseQuantity(tt.in)
+		if err != nil || got.String() != tt.want {
+			t.Errorf("ParseQuantity(%q) = %v, %v, want %s", tt.in, got, err, tt.want)
+		}
+	}
+
+	for _, in := range []string{"", "0x", "-1", "+1", "1.5", "de0b6b3a7640000", "0x1_000", "1e18"} {
+		if _, err := ParseQuantity(in); !errors.Is(err, ErrInvalidAmount) {
+			t.Errorf("ParseQuantity(%q) error = %v, want ErrInvalidAmount", in, err)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+// Package units converts between raw integer token amounts, as used by the
+// aggregator APIs, and human readable decimal amounts
+package units
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+var (
+	// ErrInvalidAmount is returned for amounts that aren't valid numbers
+	ErrInvalidAmount = errors.New("units: invalid amount")
+	// ErrPrecision is returned when an amount has more fractional digits
+	// than the token decimals allow
+	ErrPrecision = errors.New("units: amount exceeds token precision")
+)
+
+// FormatAmount formats a raw integer amount of a token with decimals as a
+// decimal string, e.g. "1500000000000000000" with 18 decimals is "1.5".
+// Trailing fractional zeros are trimmed, keeping at least one digit.
+func FormatAmount(raw string, decimals int) (string, error) {
+	if decimals < 0 {
+		return "", fmt.Errorf("units: negative decimals %d", decimals)
+	}
+
+	value, ok := new(big.Int).SetString(raw, 10)
+	if !ok {
+		return "", fmt.Errorf("%w: %q", ErrInvalidAmount, raw)
+	}
+
+	sign := ""
+	if value.Sign() < 0 {
+		sign = "-"
+		value.Abs(value)
+	}
+	if decimals == 0 {
+		return sign + value.String(), nil
+	}
+
+	whole, frac := new(big.Int).QuoRem(value, pow10(decimals), new(big.Int))
+	fracStr := fmt.Sprintf("%0*s", decimals, frac.String())
+	fracStr = strings.TrimRight(fracStr, "0")
+	if fracStr == "" {
+		fracStr = "0"
+	}
+	return sign + whole.String() + "." + fracStr, nil
+}
+
+// ParseAmount parses a decimal amount of a token with decimals into its raw
+// integer string, e.g. "1.5" with 18 decimals is "1500000000000000000". It
+// fails with ErrPrecision rather than rounding away fractional digits.
+func ParseAmount(human string, decimals int) (string, error) {
+	if decimals < 0 {
+		return "", fmt.Errorf("units: negative decimals %d", decimals)
+	}
+
+	value, ok := new(big.Rat).SetString(strings.TrimSpace(human))
+	if !ok || strings.ContainsAny(human, "/eE") {
+		return "", fmt.Errorf("%w: %q", ErrInvalidAmount, human)
+	}
+
+	value.Mul(value, new(big.Rat).SetInt(pow10(decimals)))
+	if !value.IsInt() {
+		return "", fmt.Errorf("%w: %q with %d decimals", ErrPrecision, human, decimals)
+	}
+	return value.Num().String(), nil
+}
+
+// pow10 returns 10^n
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
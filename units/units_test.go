@@ -0,0 +1,97 @@
+package units
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestFormatAmount(t *testing.T) {
+	tests := []struct {
+		raw      string
+		decimals int
+		want     string
+	}{
+		{"1000000000000000000", 18, "1.0"},
+		{"1500000000000000000", 18, "1.5"},
+		{"1", 18, "0.000000000000000001"},
+		{"0", 6, "0.0"},
+		{"123456789", 6, "123.456789"},
+		{"-2500000", 6, "-2.5"},
+		{"42", 0, "42"},
+		{"115792089237316195423570985008687907853269984665640564039457584007913129639935", 18, "115792089237316195423570985008687907853269984665640564039457.584007913129639935"},
+	}
+
+	for _, tt := range tests {
+		got, err := FormatAmount(tt.raw, tt.decimals)
+		if err != nil {
+			t.Fatalf("FormatAmount(%s, %d) error = %v", tt.raw, tt.decimals, err)
+		}
+		if got != tt.want {
+			t.Errorf("FormatAmount(%s, %d) = %s, want %s", tt.raw, tt.decimals, got, tt.want)
+		}
+	}
+}
+
+func TestFormatAmount_Invalid(t *testing.T) {
+	for _, raw := range []string{"", "1.5", "0x10", "abc"} {
+		if _, err := FormatAmount(raw, 18); !errors.Is(err, ErrInvalidAmount) {
+			t.Errorf("FormatAmount(%q) error = %v, want ErrInvalidAmount", raw, err)
+		}
+	}
+	if _, err := FormatAmount("1", -1); err == nil {
+		t.Errorf("FormatAmount() with negative decimals succeeded")
+	}
+}
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		human    string
+		decimals int
+		want     string
+	}{
+		{"1.0", 18, "1000000000000000000"},
+		{"1", 18, "1000000000000000000"},
+		{"0.000000000000000001", 18, "1"},
+		{"123.456789", 6, "123456789"},
+		{"-2.5", 6, "-2500000"},
+		{".5", 1, "5"},
+		{"42", 0, "42"},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseAmount(tt.human, tt.decimals)
+		if err != nil {
+			t.Fatalf("ParseAmount(%s, %d) error = %v", tt.human, tt.decimals, err)
+		}
+		if got != tt.want {
+			t.Errorf("ParseAmount(%s, %d) = %s, want %s", tt.human, tt.decimals, got, tt.want)
+		}
+	}
+}
+
+func TestParseAmount_Invalid(t *testing.T) {
+	for _, human := range []string{"", "abc", "1/3", "1e18", "1.2.3"} {
+		if _, err := ParseAmount(human, 18); !errors.Is(err, ErrInvalidAmount) {
+			t.Errorf("ParseAmount(%q) error = %v, want ErrInvalidAmount", human, err)
+		}
+	}
+	if _, err := ParseAmount("1.0000001", 6); !errors.Is(err, ErrPrecision) {
+		t.Errorf("ParseAmount() error = %v, want ErrPrecision", err)
+	}
+}
+
+func TestFormatParseRoundTrip(t *testing.T) {
+	for _, raw := range []string{"0", "1", "999999", "1000000000000000000", "874518826958614826"} {
+		human, err := FormatAmount(raw, 18)
+		if err != nil {
+			t.Fatalf("FormatAmount(%s) error = %v", raw, err)
+		}
+		got, err := ParseAmount(human, 18)
+		if err != nil {
+			t.Fatalf("ParseAmount(%s) error = %v", human, err)
+		}
+		if got != raw {
+			t.Errorf("round trip of %s = %s", raw, got)
+		}
+	}
+}